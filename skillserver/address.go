@@ -0,0 +1,73 @@
+package skillserver
+
+import "net/url"
+
+// Details about the Device Address API can be found on this page:
+// https://developer.amazon.com/docs/custom-skills/device-address-api.html
+
+const (
+	// FullAddressScope is the permission needed to read the full address of a device.
+	FullAddressScope = "read::alexa:device:all:address"
+
+	// CountryAndPostalCodeScope is the permission needed to read the country and postal code of a device.
+	CountryAndPostalCodeScope = "read::alexa:device:all:address:country_and_postal_code"
+)
+
+// DeviceAddress is the full address the user set for a device.
+type DeviceAddress struct {
+	AddressLine1     string `json:"addressLine1"`
+	AddressLine2     string `json:"addressLine2"`
+	AddressLine3     string `json:"addressLine3"`
+	City             string `json:"city"`
+	StateOrRegion    string `json:"stateOrRegion"`
+	DistrictOrCounty string `json:"districtOrCounty"`
+	CountryCode      string `json:"countryCode"`
+	PostalCode       string `json:"postalCode"`
+}
+
+// CountryAndPostalCode is the part of the device address available with the CountryAndPostalCodeScope permission.
+type CountryAndPostalCode struct {
+	CountryCode string `json:"countryCode"`
+	PostalCode  string `json:"postalCode"`
+}
+
+// AddressClient reads the address of a single device through the Device Address API. Both methods return
+// ErrPermissionMissing if the user has not granted the needed scope.
+type AddressClient struct {
+	api      *APIClient
+	deviceID string
+}
+
+// NewAddressClient returns an AddressClient for the device using the API client.
+func NewAddressClient(api *APIClient, deviceID string) *AddressClient {
+	return &AddressClient{api: api, deviceID: deviceID}
+}
+
+// DeviceAddress returns an AddressClient for the device the request was sent from.
+func (r *EchoRequest) DeviceAddress() *AddressClient {
+	return NewAddressClient(r.APIClient(), r.Context.System.Device.DeviceID)
+}
+
+// FullAddress returns the full address of the device. All fields are empty if the user hasn't set one.
+func (c *AddressClient) FullAddress() (*DeviceAddress, error) {
+	address := &DeviceAddress{}
+	if err := c.api.Do("GET", c.path(), nil, nil, address); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return address, nil
+}
+
+// CountryAndPostalCode returns the country and postal code of the device.
+func (c *AddressClient) CountryAndPostalCode() (*CountryAndPostalCode, error) {
+	address := &CountryAndPostalCode{}
+	if err := c.api.Do("GET", c.path()+"/countryAndPostalCode", nil, nil, address); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return address, nil
+}
+
+func (c *AddressClient) path() string {
+	return "/v1/devices/" + url.PathEscape(c.deviceID) + "/settings/address"
+}
@@ -0,0 +1,60 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestDeviceAddress(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	req := server.EchoRequest()
+	server.SetAddress(req.Context.System.Device.DeviceID, alexa.DeviceAddress{
+		AddressLine1: "410 Terry Ave North",
+		City:         "Seattle",
+		CountryCode:  "US",
+		PostalCode:   "98109",
+	})
+
+	full, err := req.DeviceAddress().FullAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if full.AddressLine1 != "410 Terry Ave North" || full.City != "Seattle" {
+		t.Fatalf("full address = %+v", full)
+	}
+
+	short, err := req.DeviceAddress().CountryAndPostalCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if short.CountryCode != "US" || short.PostalCode != "98109" {
+		t.Fatalf("country and postal code = %+v", short)
+	}
+}
+
+func TestDeviceAddressPermissionMissing(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	req := server.EchoRequest()
+	server.SetPermission(alexa.FullAddressScope, false)
+
+	if _, err := req.DeviceAddress().FullAddress(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("FullAddress without permission returned %v, want ErrPermissionMissing", err)
+	}
+
+	if _, err := req.DeviceAddress().CountryAndPostalCode(); err != nil {
+		t.Fatalf("CountryAndPostalCode with its own permission returned %v", err)
+	}
+
+	server.SetPermission(alexa.CountryAndPostalCodeScope, false)
+	if _, err := req.DeviceAddress().CountryAndPostalCode(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("CountryAndPostalCode without permission returned %v, want ErrPermissionMissing", err)
+	}
+}
@@ -0,0 +1,48 @@
+package alexatest
+
+import (
+	"net/http"
+	"strings"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// SetAddress sets the address returned for the device by the Device Address API.
+func (s *Server) SetAddress(deviceID string, address alexa.DeviceAddress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.addresses == nil {
+		s.addresses = map[string]alexa.DeviceAddress{}
+	}
+
+	s.addresses[deviceID] = address
+}
+
+func (s *Server) handleAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" || !strings.Contains(r.URL.Path, "/settings/address") {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown device API.")
+		return
+	}
+
+	deviceID := lastSegment(r.URL.Path, "/v1/devices/")
+
+	s.mu.Lock()
+	address := s.addresses[deviceID]
+	s.mu.Unlock()
+
+	if strings.HasSuffix(r.URL.Path, "/countryAndPostalCode") {
+		if !s.requirePermission(w, alexa.CountryAndPostalCodeScope) {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, alexa.CountryAndPostalCode{CountryCode: address.CountryCode, PostalCode: address.PostalCode})
+		return
+	}
+
+	if !s.requirePermission(w, alexa.FullAddressScope) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, address)
+}
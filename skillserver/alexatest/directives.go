@@ -0,0 +1,40 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// ProgressiveResponses returns the progressive responses sent to the Directive Service API so far.
+func (s *Server) ProgressiveResponses() []alexa.ProgressiveResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]alexa.ProgressiveResponse(nil), s.progressive...)
+}
+
+func (s *Server) handleDirectives(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	var body alexa.ProgressiveResponse
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_DIRECTIVE", err.Error())
+		return
+	}
+
+	if body.Header.RequestID == "" || body.Directive.Type != "VoicePlayer.Speak" || body.Directive.Speech == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_DIRECTIVE", "The directive is missing a request ID, type or speech.")
+		return
+	}
+
+	s.mu.Lock()
+	s.progressive = append(s.progressive, body)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,273 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// fakeList is a household list kept by the stand-in, with its items in the order they were added.
+type fakeList struct {
+	list  alexa.HouseholdList
+	items []*alexa.ListItem
+}
+
+// ListItems returns the items of the list with the status, or every item when the status is empty.
+func (s *Server) ListItems(listID string, status alexa.ListItemStatus) []alexa.ListItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listItems(listID, status)
+}
+
+// AddListItem adds an item to the list as if the user had added it, and returns it. Nothing is added if
+// the list does not exist.
+func (s *Server) AddListItem(listID, value string) alexa.ListItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.householdLists()[listID]
+	if !ok {
+		return alexa.ListItem{}
+	}
+
+	return *s.addListItem(l, value, alexa.ItemActive)
+}
+
+// ListEvent returns an AlexaHouseholdListEvent request such as `alexa.ListItemsCreatedEvent` about the list
+// and items, whose API endpoint and access token point at the server.
+func (s *Server) ListEvent(eventType, listID string, itemIDs ...string) *alexa.EchoRequest {
+	req := s.eventRequest(eventType)
+	req.Request.Body, _ = json.Marshal(alexa.EchoListEventBody{ListID: listID, ListItemIDs: itemIDs})
+
+	return req
+}
+
+// householdLists returns the lists of the user, creating the shopping and to-do lists every user has on
+// first use. The lock must be held.
+func (s *Server) householdLists() map[string]*fakeList {
+	if s.lists == nil {
+		s.lists = map[string]*fakeList{}
+		s.addList(alexa.ShoppingListName)
+		s.addList(alexa.ToDoListName)
+	}
+
+	return s.lists
+}
+
+func (s *Server) addList(name string) *fakeList {
+	s.listCount++
+	l := &fakeList{list: alexa.HouseholdList{
+		ListID:  "list-" + strconv.Itoa(s.listCount),
+		Name:    name,
+		State:   alexa.ListActive,
+		Version: 1,
+	}}
+
+	s.lists[l.list.ListID] = l
+	s.listOrder = append(s.listOrder, l.list.ListID)
+
+	return l
+}
+
+func (s *Server) addListItem(l *fakeList, value string, status alexa.ListItemStatus) *alexa.ListItem {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	s.listCount++
+	item := &alexa.ListItem{
+		ID:          "item-" + strconv.Itoa(s.listCount),
+		Version:     1,
+		Value:       value,
+		Status:      status,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+
+	l.items = append(l.items, item)
+
+	return item
+}
+
+func (s *Server) listItems(listID string, status alexa.ListItemStatus) []alexa.ListItem {
+	items := []alexa.ListItem{}
+
+	l, ok := s.householdLists()[listID]
+	if !ok {
+		return items
+	}
+
+	for _, item := range l.items {
+		if status == "" || item.Status == status {
+			items = append(items, *item)
+		}
+	}
+
+	return items
+}
+
+func (s *Server) handleLists(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v2/householdlists"), "/"), "/")
+
+	scope := alexa.ListWriteScope
+	if r.Method == "GET" {
+		scope = alexa.ListReadScope
+	}
+
+	if !s.requirePermission(w, scope) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lists := s.householdLists()
+
+	if parts[0] == "" {
+		switch r.Method {
+		case "GET":
+			all := []alexa.HouseholdList{}
+			for _, id := range s.listOrder {
+				list := lists[id].list
+				for _, status := range []alexa.ListItemStatus{alexa.ItemActive, alexa.ItemCompleted} {
+					list.StatusMap = append(list.StatusMap, alexa.ListStatusLink{Href: "/v2/householdlists/" + id + "/" + string(status), Status: status})
+				}
+
+				all = append(all, list)
+			}
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{"lists": all})
+		case "POST":
+			var body alexa.HouseholdList
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+				writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The list name is missing.")
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, s.addList(body.Name).list)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		}
+
+		return
+	}
+
+	l, ok := lists[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No list exists for the ID.")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		s.handleList(w, r, l)
+	case len(parts) == 2 && parts[1] != "items" && r.Method == "GET":
+		list := l.list
+		list.Items = s.listItems(list.ListID, alexa.ListItemStatus(parts[1]))
+		writeJSON(w, http.StatusOK, list)
+	case len(parts) == 2 && parts[1] == "items" && r.Method == "POST":
+		var body alexa.ListItem
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Value == "" {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The item value is missing.")
+			return
+		}
+
+		if body.Status == "" {
+			body.Status = alexa.ItemActive
+		}
+
+		writeJSON(w, http.StatusCreated, s.addListItem(l, body.Value, body.Status))
+	case len(parts) == 3 && parts[1] == "items":
+		s.handleListItem(w, r, l, parts[2])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown list API.")
+	}
+}
+
+// handleList serves a single list. The lock must be held.
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, l *fakeList) {
+	switch r.Method {
+	case "PUT":
+		var body alexa.HouseholdList
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		if body.Version != l.list.Version {
+			writeError(w, http.StatusConflict, "CONFLICT", "The list version is outdated.")
+			return
+		}
+
+		l.list.Name = body.Name
+		l.list.State = body.State
+		l.list.Version++
+
+		writeJSON(w, http.StatusOK, l.list)
+	case "DELETE":
+		if l.list.Name == alexa.ShoppingListName || l.list.Name == alexa.ToDoListName {
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "The default lists can't be deleted.")
+			return
+		}
+
+		delete(s.lists, l.list.ListID)
+		for i, id := range s.listOrder {
+			if id == l.list.ListID {
+				s.listOrder = append(s.listOrder[:i], s.listOrder[i+1:]...)
+				break
+			}
+		}
+
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+	}
+}
+
+// handleListItem serves a single item of a list. The lock must be held.
+func (s *Server) handleListItem(w http.ResponseWriter, r *http.Request, l *fakeList, itemID string) {
+	index := -1
+	for i, item := range l.items {
+		if item.ID == itemID {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No item exists for the ID.")
+		return
+	}
+
+	item := l.items[index]
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, item)
+	case "PUT":
+		var body alexa.ListItem
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		if body.Version != item.Version {
+			writeError(w, http.StatusConflict, "CONFLICT", "The item version is outdated.")
+			return
+		}
+
+		item.Value = body.Value
+		item.Status = body.Status
+		item.Version++
+		item.UpdatedTime = time.Now().UTC().Format(time.RFC3339)
+
+		writeJSON(w, http.StatusOK, item)
+	case "DELETE":
+		l.items = append(l.items[:index], l.items[index+1:]...)
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+	}
+}
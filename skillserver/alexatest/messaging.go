@@ -0,0 +1,77 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// SentMessage is a skill message received by the server.
+type SentMessage struct {
+	UserID              string
+	Data                json.RawMessage
+	ExpiresAfterSeconds int
+}
+
+// MessagingClient returns a client sending skill messages to the server with the test client credentials.
+func (s *Server) MessagingClient() *alexa.MessagingClient {
+	return &alexa.MessagingClient{TokenSource: s.TokenSource(alexa.SkillMessagingScope), Endpoint: s.URL}
+}
+
+// Messages returns the skill messages received by the server, in the order they were sent.
+func (s *Server) Messages() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SentMessage{}, s.messages...)
+}
+
+// MessageReceived returns the Messaging.MessageReceived request Alexa sends to the skill for the message,
+// whose API endpoint and access token point at the server.
+func (s *Server) MessageReceived(message SentMessage) *alexa.EchoRequest {
+	req := s.eventRequest("Messaging.MessageReceived")
+	req.Context.System.User.UserID = message.UserID
+	req.Request.Message = message.Data
+
+	return req
+}
+
+func (s *Server) handleSkillMessages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	var body struct {
+		Data                json.RawMessage `json:"data"`
+		ExpiresAfterSeconds int             `json:"expiresAfterSeconds"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Data) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The message data is missing.")
+		return
+	}
+
+	if body.ExpiresAfterSeconds == 0 {
+		body.ExpiresAfterSeconds = 3600
+	}
+
+	if body.ExpiresAfterSeconds < 60 || body.ExpiresAfterSeconds > 86400 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "expiresAfterSeconds must be between 60 and 86400.")
+		return
+	}
+
+	userID := strings.TrimPrefix(r.URL.Path, "/v1/skillmessages/users/")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The user ID is missing.")
+		return
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, SentMessage{UserID: userID, Data: body.Data, ExpiresAfterSeconds: body.ExpiresAfterSeconds})
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,108 @@
+package alexatest
+
+import (
+	"net/http"
+	"strconv"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// AddProduct adds an in-skill product returned by the Monetization Service API.
+func (s *Server) AddProduct(product alexa.InSkillProduct) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.products = append(s.products, product)
+}
+
+// SetEntitled changes whether the user owns the product.
+func (s *Server) SetEntitled(productID string, entitled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.products {
+		if s.products[i].ProductID != productID {
+			continue
+		}
+
+		if entitled {
+			s.products[i].Entitled = alexa.Entitled
+			s.products[i].ActiveEntitlementCount = 1
+		} else {
+			s.products[i].Entitled = alexa.NotEntitled
+			s.products[i].ActiveEntitlementCount = 0
+		}
+	}
+}
+
+func (s *Server) handleProducts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	var matched []alexa.InSkillProduct
+	for _, product := range s.products {
+		if t := query.Get("productType"); t != "" && string(product.Type) != t {
+			continue
+		}
+
+		if p := query.Get("purchasable"); p != "" && product.Purchasable != p {
+			continue
+		}
+
+		if e := query.Get("entitled"); e != "" && product.Entitled != e {
+			continue
+		}
+
+		matched = append(matched, product)
+	}
+	s.mu.Unlock()
+
+	start, _ := strconv.Atoi(query.Get("nextToken"))
+	if start > len(matched) {
+		start = len(matched)
+	}
+
+	size, err := strconv.Atoi(query.Get("maxResults"))
+	if err != nil || size <= 0 {
+		size = 100
+	}
+
+	page := alexa.InSkillProductsPage{InSkillProducts: []alexa.InSkillProduct{}}
+	end := start + size
+	if end < len(matched) {
+		page.IsTruncated = true
+		page.NextToken = strconv.Itoa(end)
+	} else {
+		end = len(matched)
+	}
+
+	page.InSkillProducts = append(page.InSkillProducts, matched[start:end]...)
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleProduct(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	productID := lastSegment(r.URL.Path, "/v1/users/~current/skills/~current/inSkillProducts/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, product := range s.products {
+		if product.ProductID == productID {
+			writeJSON(w, http.StatusOK, product)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "The product does not exist.")
+}
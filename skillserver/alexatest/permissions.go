@@ -0,0 +1,30 @@
+package alexatest
+
+import "net/http"
+
+// SetPermission grants or revokes a permission scope. All scopes are granted until they are revoked, and
+// APIs guarded by a revoked scope respond with a 403 as the real APIs do.
+func (s *Server) SetPermission(scope string, granted bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.revoked == nil {
+		s.revoked = map[string]bool{}
+	}
+
+	s.revoked[scope] = !granted
+}
+
+// requirePermission writes a 403 and returns false if the scope was revoked.
+func (s *Server) requirePermission(w http.ResponseWriter, scope string) bool {
+	s.mu.Lock()
+	revoked := s.revoked[scope]
+	s.mu.Unlock()
+
+	if revoked {
+		writeError(w, http.StatusForbidden, "ACCESS_DENIED", "The user has not granted the "+scope+" permission.")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,140 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// Client credentials accepted by the token endpoint of the server.
+const (
+	ClientID     = "test-client"
+	ClientSecret = "test-secret"
+)
+
+// ProactiveEventsClient returns a client sending events to the server with the test client credentials.
+func (s *Server) ProactiveEventsClient(sandbox bool) *alexa.ProactiveEventsClient {
+	return &alexa.ProactiveEventsClient{
+		TokenSource: s.TokenSource(alexa.ProactiveEventsScope),
+		Endpoint:    s.URL,
+		Sandbox:     sandbox,
+	}
+}
+
+// ProactiveEvents returns the events sent to the development stage when sandbox is true, or to live users otherwise.
+func (s *Server) ProactiveEvents(sandbox bool) []alexa.ProactiveEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sandbox {
+		return append([]alexa.ProactiveEvent{}, s.sandboxEvents...)
+	}
+
+	return append([]alexa.ProactiveEvent{}, s.liveEvents...)
+}
+
+// TokenSource returns a token source requesting tokens with the scopes from the token endpoint of the
+// server, using the test client credentials.
+func (s *Server) TokenSource(scopes ...string) *alexa.LWATokenSource {
+	source := alexa.NewLWATokenSource(ClientID, ClientSecret, scopes...)
+	source.Endpoint = s.URL + "/auth/o2/token"
+
+	return source
+}
+
+// SetTokenLifetime sets how long the tokens handed out by the token endpoint are valid. It is an hour
+// unless changed.
+func (s *Server) SetTokenLifetime(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokenLifetime = d
+}
+
+// TokenRequests returns the number of access tokens handed out by the token endpoint.
+func (s *Server) TokenRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokenRequests
+}
+
+// handleToken serves the Login with Amazon client credentials grant. It is reached without a bearer token.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "invalid_request", "Method not allowed.")
+		return
+	}
+
+	if r.FormValue("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	if r.FormValue("client_id") != ClientID || r.FormValue("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client", "error_description": "Client authentication failed"})
+		return
+	}
+
+	if r.FormValue("scope") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_scope", "error_description": "A scope is required"})
+		return
+	}
+
+	s.mu.Lock()
+	s.tokenRequests++
+	lifetime := s.tokenLifetime
+	s.mu.Unlock()
+
+	if lifetime == 0 {
+		lifetime = time.Hour
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": s.Token,
+		"expires_in":   int(lifetime / time.Second),
+		"scope":        r.FormValue("scope"),
+		"token_type":   "bearer",
+	})
+}
+
+func (s *Server) handleProactiveEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	var event alexa.ProactiveEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	audience := event.RelevantAudience
+	switch {
+	case event.Event.Name == "" || event.Event.Payload == nil:
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The event name or payload is missing.")
+		return
+	case event.ReferenceID == "" || event.Timestamp == "" || event.ExpiryTime == "":
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "The reference ID, timestamp or expiry time is missing.")
+		return
+	case audience.Type == "Unicast" && audience.Payload["user"] == "":
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Unicast events need a user.")
+		return
+	case audience.Type != "Unicast" && audience.Type != "Multicast":
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Unknown audience type.")
+		return
+	}
+
+	s.mu.Lock()
+	if r.URL.Path == "/v1/proactiveEvents/stages/development" {
+		s.sandboxEvents = append(s.sandboxEvents, event)
+	} else {
+		s.liveEvents = append(s.liveEvents, event)
+	}
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
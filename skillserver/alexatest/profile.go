@@ -0,0 +1,76 @@
+package alexatest
+
+import (
+	"net/http"
+	"strings"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// SetCustomerProfile sets the contact information returned for the account by the Customer Profile API.
+func (s *Server) SetCustomerProfile(profile alexa.Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.customer = profile
+}
+
+// SetPersonProfile sets the contact information returned for the recognized person. Until it is set,
+// person profile requests respond as if no person was recognized.
+func (s *Server) SetPersonProfile(profile alexa.Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.person = &profile
+}
+
+func (s *Server) handleCustomerProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	profile := s.customer
+	s.mu.Unlock()
+
+	s.writeProfileField(w, r, profile, strings.TrimPrefix(r.URL.Path, "/v2/accounts/~current/settings/Profile."), true)
+}
+
+func (s *Server) handlePersonProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	profile := s.person
+	s.mu.Unlock()
+
+	if profile == nil {
+		writeError(w, http.StatusForbidden, "ACCESS_DENIED", "No person was recognized.")
+		return
+	}
+
+	s.writeProfileField(w, r, *profile, strings.TrimPrefix(r.URL.Path, "/v2/persons/~current/profile/"), false)
+}
+
+func (s *Server) writeProfileField(w http.ResponseWriter, r *http.Request, profile alexa.Profile, field string, hasEmail bool) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+		return
+	}
+
+	var scope string
+	var value interface{}
+
+	switch {
+	case field == "name":
+		scope, value = alexa.NameScope, profile.Name
+	case field == "givenName":
+		scope, value = alexa.GivenNameScope, profile.GivenName
+	case field == "email" && hasEmail:
+		scope, value = alexa.EmailScope, profile.Email
+	case field == "mobileNumber":
+		scope, value = alexa.MobileNumberScope, profile.MobileNumber
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown profile field.")
+		return
+	}
+
+	if !s.requirePermission(w, scope) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, value)
+}
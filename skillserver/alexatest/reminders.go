@@ -0,0 +1,164 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// SetMaxReminders limits the number of reminders the user can have. Creating more fails with
+// MAX_REMINDERS_EXCEEDED. A limit of 0 allows any number.
+func (s *Server) SetMaxReminders(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxReminders = n
+}
+
+// Reminders returns the reminders currently set, in the order they were created.
+func (s *Server) Reminders() []alexa.Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reminders := []alexa.Reminder{}
+	for _, token := range s.reminderOrder {
+		reminders = append(reminders, *s.reminders[token])
+	}
+
+	return reminders
+}
+
+func (s *Server) handleReminders(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, alexa.RemindersScope) {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		reminders := s.Reminders()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"totalCount": strconv.Itoa(len(reminders)),
+			"alerts":     reminders,
+		})
+	case "POST":
+		reminder, ok := decodeReminder(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		if s.maxReminders > 0 && len(s.reminderOrder) >= s.maxReminders {
+			s.mu.Unlock()
+			writeError(w, http.StatusForbidden, "MAX_REMINDERS_EXCEEDED", "The user has reached the maximum number of reminders.")
+			return
+		}
+
+		if s.reminders == nil {
+			s.reminders = map[string]*alexa.Reminder{}
+		}
+
+		s.reminderCount++
+		reminder.AlertToken = "reminder-" + strconv.Itoa(s.reminderCount)
+		reminder.CreatedTime = reminder.UpdatedTime
+		reminder.Version = "1"
+		s.reminders[reminder.AlertToken] = reminder
+		s.reminderOrder = append(s.reminderOrder, reminder.AlertToken)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, reminder)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+	}
+}
+
+func (s *Server) handleReminder(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, alexa.RemindersScope) {
+		return
+	}
+
+	token := lastSegment(r.URL.Path, "/v1/alerts/reminders/")
+
+	s.mu.Lock()
+	existing, found := s.reminders[token]
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "ALERT_NOT_FOUND", "No reminder exists for the alert token.")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		s.mu.Lock()
+		reminder := *existing
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, reminder)
+	case "PUT":
+		reminder, ok := decodeReminder(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		version, _ := strconv.Atoi(existing.Version)
+		reminder.AlertToken = token
+		reminder.CreatedTime = existing.CreatedTime
+		reminder.Version = strconv.Itoa(version + 1)
+		s.reminders[token] = reminder
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, reminder)
+	case "DELETE":
+		s.mu.Lock()
+		delete(s.reminders, token)
+		for i, t := range s.reminderOrder {
+			if t == token {
+				s.reminderOrder = append(s.reminderOrder[:i], s.reminderOrder[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+	}
+}
+
+// decodeReminder reads a reminder from the request body and checks it the way the Reminders API does,
+// writing a 400 and returning false if it is invalid.
+func decodeReminder(w http.ResponseWriter, r *http.Request) (*alexa.Reminder, bool) {
+	reminder := &alexa.Reminder{}
+	if err := json.NewDecoder(r.Body).Decode(reminder); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return nil, false
+	}
+
+	trigger := reminder.Trigger
+	switch {
+	case trigger == nil:
+		writeError(w, http.StatusBadRequest, "INVALID_TRIGGER", "The trigger is missing.")
+		return nil, false
+	case trigger.Type == alexa.TriggerAbsolute && trigger.ScheduledTime == "":
+		writeError(w, http.StatusBadRequest, "INVALID_TRIGGER", "The scheduled time is missing.")
+		return nil, false
+	case trigger.Type == alexa.TriggerRelative && trigger.OffsetInSeconds <= 0:
+		writeError(w, http.StatusBadRequest, "INVALID_TRIGGER", "The offset must be positive.")
+		return nil, false
+	case trigger.Type != alexa.TriggerAbsolute && trigger.Type != alexa.TriggerRelative:
+		writeError(w, http.StatusBadRequest, "INVALID_TRIGGER", "Unknown trigger type.")
+		return nil, false
+	case len(reminder.AlertInfo.SpokenInfo.Content) == 0:
+		writeError(w, http.StatusBadRequest, "INVALID_ALERT_INFO", "The reminder has no content.")
+		return nil, false
+	}
+
+	reminder.Status = "ON"
+	reminder.UpdatedTime = time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	return reminder, true
+}
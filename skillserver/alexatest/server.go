@@ -0,0 +1,138 @@
+// Package alexatest provides a local stand-in for the Alexa APIs the skillserver clients call, so skill
+// handlers using them can be tested without reaching Amazon.
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// Server is an in-memory stand-in for the Alexa APIs. Its state can be set up and inspected through its
+// fields and methods, which are safe to use while requests are being served.
+type Server struct {
+	*httptest.Server
+
+	// Token is the access token the server accepts. Requests with any other bearer token get a 401.
+	Token string
+
+	mu  sync.Mutex
+	mux *http.ServeMux
+
+	revoked     map[string]bool
+	products    []alexa.InSkillProduct
+	progressive []alexa.ProgressiveResponse
+	addresses   map[string]alexa.DeviceAddress
+	customer    alexa.Profile
+	person      *alexa.Profile
+
+	reminders     map[string]*alexa.Reminder
+	reminderOrder []string
+	reminderCount int
+	maxReminders  int
+
+	timers     map[string]*alexa.Timer
+	timerOrder []string
+	timerCount int
+	maxTimers  int
+
+	lists     map[string]*fakeList
+	listOrder []string
+	listCount int
+
+	tokenRequests int
+	tokenLifetime time.Duration
+	sandboxEvents []alexa.ProactiveEvent
+	liveEvents    []alexa.ProactiveEvent
+	messages      []SentMessage
+}
+
+// NewServer starts a Server accepting "test-token". Call Close when done with it.
+func NewServer() *Server {
+	s := &Server{Token: "test-token", mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("/v1/users/~current/skills/~current/inSkillProducts", s.handleProducts)
+	s.mux.HandleFunc("/v1/users/~current/skills/~current/inSkillProducts/", s.handleProduct)
+	s.mux.HandleFunc("/v1/directives", s.handleDirectives)
+	s.mux.HandleFunc("/v1/devices/", s.handleAddress)
+	s.mux.HandleFunc("/v2/accounts/~current/settings/", s.handleCustomerProfile)
+	s.mux.HandleFunc("/v2/persons/~current/profile/", s.handlePersonProfile)
+	s.mux.HandleFunc("/v1/alerts/reminders", s.handleReminders)
+	s.mux.HandleFunc("/v1/alerts/reminders/", s.handleReminder)
+	s.mux.HandleFunc("/v1/alerts/timers", s.handleTimers)
+	s.mux.HandleFunc("/v1/alerts/timers/", s.handleTimer)
+	s.mux.HandleFunc("/v2/householdlists/", s.handleLists)
+	s.mux.HandleFunc("/v1/proactiveEvents", s.handleProactiveEvents)
+	s.mux.HandleFunc("/v1/proactiveEvents/stages/development", s.handleProactiveEvents)
+	s.mux.HandleFunc("/v1/skillmessages/users/", s.handleSkillMessages)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// EchoRequest returns a request whose API endpoint and access token point at the server, as the Alexa
+// service would send for a user of the skill.
+func (s *Server) EchoRequest() *alexa.EchoRequest {
+	req := &alexa.EchoRequest{Version: "1.0"}
+	req.Session.SessionID = "amzn1.echo-api.session.test"
+	req.Session.User.UserID = "amzn1.ask.account.test"
+	req.Context.System.User.UserID = "amzn1.ask.account.test"
+	req.Context.System.Device.DeviceID = "amzn1.ask.device.test"
+	req.Context.System.APIEndpoint = s.URL
+	req.Context.System.APIAccessToken = s.Token
+	req.Request.RequestID = "amzn1.echo-api.request.test"
+	req.Request.Locale = "en-US"
+
+	return req
+}
+
+// eventRequest returns a request of the type sent outside of a session, such as a skill event, whose API
+// endpoint and access token point at the server.
+func (s *Server) eventRequest(requestType string) *alexa.EchoRequest {
+	req := s.EchoRequest()
+	req.Session = alexa.EchoRequest{}.Session
+	req.Request.Type = requestType
+	req.Request.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	req.Request.EventCreationTime = req.Request.Timestamp
+	req.Request.EventPublishingTime = req.Request.Timestamp
+
+	return req
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/auth/o2/token" {
+		s.handleToken(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "INVALID_ACCESS_TOKEN", "The access token is missing or invalid.")
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
+
+// lastSegment returns the part of the path after the prefix, up to the next slash.
+func lastSegment(path, prefix string) string {
+	return strings.SplitN(strings.TrimPrefix(path, prefix), "/", 2)[0]
+}
@@ -0,0 +1,26 @@
+package alexatest
+
+import (
+	"encoding/json"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// SkillEvent returns an AlexaSkillEvent request such as `alexa.SkillPermissionChangedEvent` with the body,
+// whose API endpoint and access token point at the server.
+func (s *Server) SkillEvent(eventType string, body alexa.EchoSkillEventBody) *alexa.EchoRequest {
+	req := s.eventRequest(eventType)
+	req.Request.Body, _ = json.Marshal(body)
+
+	return req
+}
+
+// PermissionEvent returns an AlexaSkillEvent request of the type granting the scopes.
+func (s *Server) PermissionEvent(eventType string, scopes ...string) *alexa.EchoRequest {
+	body := alexa.EchoSkillEventBody{}
+	for _, scope := range scopes {
+		body.AcceptedPermissions = append(body.AcceptedPermissions, alexa.EchoPermission{Scope: scope})
+	}
+
+	return s.SkillEvent(eventType, body)
+}
@@ -0,0 +1,156 @@
+package alexatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// timerTimeLayout is the format of the times returned by the Timers API.
+const timerTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// SetMaxTimers limits the number of timers the user can have. Starting more fails with MAX_TIMERS_EXCEEDED.
+// A limit of 0 allows any number.
+func (s *Server) SetMaxTimers(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxTimers = n
+}
+
+// Timers returns the timers currently set, in the order they were started. Timers don't run down in the
+// stand-in; their status only changes when they are paused, resumed or cancelled.
+func (s *Server) Timers() []alexa.Timer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timers := []alexa.Timer{}
+	for _, id := range s.timerOrder {
+		timers = append(timers, *s.timers[id])
+	}
+
+	return timers
+}
+
+func (s *Server) handleTimers(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, alexa.TimersScope) {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		timers := s.Timers()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timers": timers, "totalCount": len(timers)})
+	case "POST":
+		timer := &alexa.Timer{}
+		if err := json.NewDecoder(r.Body).Decode(timer); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		d, err := alexa.ParseDuration(timer.Duration)
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, "INVALID_DURATION", "The duration is missing or invalid.")
+			return
+		}
+
+		if timer.TriggeringBehavior == nil {
+			writeError(w, http.StatusBadRequest, "INVALID_TRIGGERING_BEHAVIOR", "The triggering behavior is missing.")
+			return
+		}
+
+		s.mu.Lock()
+		if s.maxTimers > 0 && len(s.timerOrder) >= s.maxTimers {
+			s.mu.Unlock()
+			writeError(w, http.StatusBadRequest, "MAX_TIMERS_EXCEEDED", "The user has reached the maximum number of timers.")
+			return
+		}
+
+		if s.timers == nil {
+			s.timers = map[string]*alexa.Timer{}
+		}
+
+		now := time.Now().UTC()
+		s.timerCount++
+		timer.ID = "timer-" + strconv.Itoa(s.timerCount)
+		timer.Status = "ON"
+		timer.CreatedTime = now.Format(timerTimeLayout)
+		timer.UpdatedTime = timer.CreatedTime
+		timer.TriggerTime = now.Add(d).Format(timerTimeLayout)
+		s.timers[timer.ID] = timer
+		s.timerOrder = append(s.timerOrder, timer.ID)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, timer)
+	case "DELETE":
+		s.mu.Lock()
+		s.timers = nil
+		s.timerOrder = nil
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_REQUEST", "Method not allowed.")
+	}
+}
+
+func (s *Server) handleTimer(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, alexa.TimersScope) {
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/alerts/timers/")
+	id := lastSegment(path, "")
+	action := strings.TrimPrefix(path, id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timer, found := s.timers[id]
+	if !found {
+		writeError(w, http.StatusNotFound, "TIMER_NOT_FOUND", "No timer exists for the ID.")
+		return
+	}
+
+	now := time.Now().UTC()
+
+	switch {
+	case r.Method == "GET" && action == "":
+		writeJSON(w, http.StatusOK, timer)
+	case r.Method == "DELETE" && action == "":
+		delete(s.timers, id)
+		for i, t := range s.timerOrder {
+			if t == id {
+				s.timerOrder = append(s.timerOrder[:i], s.timerOrder[i+1:]...)
+				break
+			}
+		}
+
+		writeJSON(w, http.StatusOK, nil)
+	case r.Method == "POST" && action == "/pause":
+		if timer.Status == "ON" {
+			trigger, _ := time.Parse(timerTimeLayout, timer.TriggerTime)
+			timer.Status = "PAUSED"
+			timer.RemainingTimeWhenPaused = alexa.FormatDuration(trigger.Sub(now))
+			timer.UpdatedTime = now.Format(timerTimeLayout)
+		}
+
+		writeJSON(w, http.StatusOK, nil)
+	case r.Method == "POST" && action == "/resume":
+		if timer.Status == "PAUSED" {
+			remaining, _ := alexa.ParseDuration(timer.RemainingTimeWhenPaused)
+			timer.Status = "ON"
+			timer.TriggerTime = now.Add(remaining).Format(timerTimeLayout)
+			timer.RemainingTimeWhenPaused = ""
+			timer.UpdatedTime = now.Format(timerTimeLayout)
+		}
+
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown timer API.")
+	}
+}
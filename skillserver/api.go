@@ -0,0 +1,167 @@
+package skillserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrPermissionMissing is returned by the Alexa API clients when the user has not granted the skill the
+// permission the API requires. `EchoResponse.AskForPermissions` can be used to ask the user for it.
+var ErrPermissionMissing = errors.New("the user has not granted the permission required by this api")
+
+// APIError is returned by the Alexa API clients when the API responds with an error status.
+type APIError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("alexa api returned status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg
+}
+
+// APIClient makes authenticated calls to the Alexa APIs. The typed clients for the individual APIs are
+// built on top of it. A client for the user of a request can be created with `EchoRequest.APIClient`.
+type APIClient struct {
+	// Endpoint is the base URL of the Alexa APIs for the region of the user, e.g. "https://api.amazonalexa.com".
+	Endpoint string
+
+	// Token is the access token sent as a bearer token.
+	Token string
+
+	// TokenSource provides the access token instead of Token when set, e.g. an LWATokenSource for the APIs
+	// called outside of a request.
+	TokenSource TokenSource
+
+	// Locale is sent as the Accept-Language header when set, which localizes text returned by some APIs.
+	Locale string
+
+	// HTTPClient is used to make the requests. A client with a 10 second timeout is used when nil.
+	HTTPClient *http.Client
+}
+
+var defaultAPIHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// NewAPIClient returns a client for the Alexa APIs at the endpoint, authenticated with the token.
+func NewAPIClient(endpoint, token string) *APIClient {
+	return &APIClient{Endpoint: endpoint, Token: token}
+}
+
+// NewTokenSourceAPIClient returns a client for the Alexa APIs at the endpoint, authenticated with tokens
+// from the source.
+func NewTokenSourceAPIClient(endpoint string, source TokenSource) *APIClient {
+	return &APIClient{Endpoint: endpoint, TokenSource: source}
+}
+
+// APIClient returns a client calling the Alexa APIs with the endpoint, access token and locale of the request.
+func (r *EchoRequest) APIClient() *APIClient {
+	return &APIClient{
+		Endpoint: r.Context.System.APIEndpoint,
+		Token:    r.Context.System.APIAccessToken,
+		Locale:   r.Locale(),
+	}
+}
+
+// permissionError turns the 403 returned by the APIs guarded by customer permissions into ErrPermissionMissing.
+func permissionError(err error) error {
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusForbidden {
+		return ErrPermissionMissing
+	}
+
+	return err
+}
+
+// Do sends a request to the API path and decodes a JSON response into out, which can be nil. The body is
+// encoded as JSON when it is not nil. An *APIError is returned for any status code of 400 or above. A 401
+// drops the token cached by the TokenSource, so that the next call uses a new one.
+func (c *APIClient) Do(method, path string, query url.Values, body, out interface{}) error {
+	endpoint := strings.TrimRight(c.Endpoint, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+
+	token := c.Token
+	if c.TokenSource != nil {
+		if token, err = c.TokenSource.Token(); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Locale != "" {
+		req.Header.Set("Accept-Language", c.Locale)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultAPIHTTPClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		if source, ok := c.TokenSource.(interface{ Invalidate() }); ok {
+			source.Invalidate()
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(contents, apiErr)
+
+		return apiErr
+	}
+
+	if out == nil || len(contents) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(contents, out)
+}
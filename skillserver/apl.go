@@ -0,0 +1,140 @@
+package skillserver
+
+// Details about the Alexa Presentation Language (APL) can be found on this page:
+// https://developer.amazon.com/docs/alexa-presentation-language/apl-overview.html
+
+// APLInterface is the supported interface name of devices that can render APL documents.
+const APLInterface = "Alexa.Presentation.APL"
+
+const (
+	aplRenderDocument  = "Alexa.Presentation.APL.RenderDocument"
+	aplExecuteCommands = "Alexa.Presentation.APL.ExecuteCommands"
+)
+
+// APLDocument is an APL document that can be sent inline with a RenderDocument directive. Components
+// in the main template, layouts and styles are kept as generic JSON values as APL defines far too many
+// of them to model here.
+type APLDocument struct {
+	Type         string                 `json:"type"`
+	Version      string                 `json:"version"`
+	Theme        string                 `json:"theme,omitempty"`
+	Import       []APLImport            `json:"import,omitempty"`
+	Resources    []interface{}          `json:"resources,omitempty"`
+	Styles       map[string]interface{} `json:"styles,omitempty"`
+	Layouts      map[string]interface{} `json:"layouts,omitempty"`
+	MainTemplate APLTemplate            `json:"mainTemplate"`
+}
+
+// APLImport is a package imported by an APL document, such as "alexa-layouts".
+type APLImport struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// APLTemplate is the main template of an APL document. The parameters are bound to the datasources
+// of the RenderDocument directive by name.
+type APLTemplate struct {
+	Parameters []string      `json:"parameters,omitempty"`
+	Items      []interface{} `json:"items"`
+}
+
+// APLLink refers to an APL document saved in the authoring tool instead of sending it inline.
+type APLLink struct {
+	Type string `json:"type"`
+	Src  string `json:"src"`
+}
+
+// APLCommand is a single APL command, such as SpeakItem or SetValue, with its properties.
+type APLCommand map[string]interface{}
+
+// NewAPLDocument returns an empty APL document of the given APL version.
+func NewAPLDocument(version string) *APLDocument {
+	return &APLDocument{Type: "APL", Version: version, MainTemplate: APLTemplate{Items: []interface{}{}}}
+}
+
+// NewAPLLink returns a reference to a saved APL document, e.g. "doc://alexa/apl/documents/MyDocument".
+func NewAPLLink(src string) APLLink {
+	return APLLink{Type: "Link", Src: src}
+}
+
+// NewAPLCommand returns a command of the given type with the provided properties.
+func NewAPLCommand(commandType string, properties map[string]interface{}) APLCommand {
+	command := APLCommand{"type": commandType}
+	for k, v := range properties {
+		command[k] = v
+	}
+
+	return command
+}
+
+// APLRenderDocumentDirective displays an APL document on devices with a screen.
+type APLRenderDocumentDirective struct {
+	Type        string                 `json:"type"`
+	Token       string                 `json:"token,omitempty"`
+	Document    interface{}            `json:"document"`
+	Datasources map[string]interface{} `json:"datasources,omitempty"`
+	Sources     map[string]interface{} `json:"sources,omitempty"`
+}
+
+// DirectiveType implements Directive.
+func (d *APLRenderDocumentDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *APLRenderDocumentDirective) RequiredInterface() string {
+	return APLInterface
+}
+
+// APLExecuteCommandsDirective runs commands against the APL document rendered with the same token.
+type APLExecuteCommandsDirective struct {
+	Type     string       `json:"type"`
+	Token    string       `json:"token"`
+	Commands []APLCommand `json:"commands"`
+}
+
+// DirectiveType implements Directive.
+func (d *APLExecuteCommandsDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *APLExecuteCommandsDirective) RequiredInterface() string {
+	return APLInterface
+}
+
+// RenderAPLDocument adds a RenderDocument directive to the response. The document can be an *APLDocument,
+// an APLLink or raw JSON. The token identifies the document in later ExecuteCommands directives and
+// UserEvent requests. The directive is dropped for devices that don't support APL.
+func (r *EchoResponse) RenderAPLDocument(token string, document interface{}, datasources map[string]interface{}) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &APLRenderDocumentDirective{
+		Type:        aplRenderDocument,
+		Token:       token,
+		Document:    document,
+		Datasources: datasources,
+	})
+
+	return r
+}
+
+// ExecuteAPLCommands adds an ExecuteCommands directive for the document rendered with the token.
+func (r *EchoResponse) ExecuteAPLCommands(token string, commands ...APLCommand) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &APLExecuteCommandsDirective{
+		Type:     aplExecuteCommands,
+		Token:    token,
+		Commands: commands,
+	})
+
+	return r
+}
+
+// GetAPLArguments is a convenience method for getting the arguments of the SendEvent command that caused
+// an Alexa.Presentation.APL.UserEvent request.
+func (r *EchoRequest) GetAPLArguments() []interface{} {
+	return r.Request.Arguments
+}
+
+// GetAPLToken returns the token of the APL document that sent an Alexa.Presentation.APL.UserEvent request.
+func (r *EchoRequest) GetAPLToken() string {
+	return r.Request.Token
+}
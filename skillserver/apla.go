@@ -0,0 +1,136 @@
+package skillserver
+
+// Details about APL for Audio (APLA) can be found on this page:
+// https://developer.amazon.com/docs/alexa/alexa-presentation-language/apl-for-audio-reference.html
+
+const aplaRenderDocument = "Alexa.Presentation.APLA.RenderDocument"
+
+// APLAComponent is implemented by the components an APLA document is built from.
+type APLAComponent interface {
+	aplaComponent()
+}
+
+// APLADocument is an APL for Audio document. Its main template usually holds a single Mixer or
+// Sequencer combining the other components.
+type APLADocument struct {
+	Type         string       `json:"type"`
+	Version      string       `json:"version"`
+	MainTemplate APLATemplate `json:"mainTemplate"`
+}
+
+// APLATemplate is the main template of an APLA document.
+type APLATemplate struct {
+	Parameters []string        `json:"parameters,omitempty"`
+	Item       APLAComponent   `json:"item,omitempty"`
+	Items      []APLAComponent `json:"items,omitempty"`
+}
+
+// APLAMixer plays all of its items at the same time, e.g. speech over background music.
+type APLAMixer struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id,omitempty"`
+	When  string          `json:"when,omitempty"`
+	Items []APLAComponent `json:"items"`
+}
+
+// APLASequencer plays its items one after the other.
+type APLASequencer struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id,omitempty"`
+	When  string          `json:"when,omitempty"`
+	Items []APLAComponent `json:"items"`
+}
+
+// APLASpeech speaks plain text or SSML.
+type APLASpeech struct {
+	Type        string `json:"type"`
+	ID          string `json:"id,omitempty"`
+	When        string `json:"when,omitempty"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+// APLAAudio plays an audio file from a URL or the Alexa sound library.
+type APLAAudio struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id,omitempty"`
+	When    string        `json:"when,omitempty"`
+	Source  string        `json:"source"`
+	Filters []interface{} `json:"filters,omitempty"`
+}
+
+// APLASilence plays nothing for the given number of milliseconds.
+type APLASilence struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	When     string `json:"when,omitempty"`
+	Duration int    `json:"duration"`
+}
+
+func (*APLAMixer) aplaComponent()     {}
+func (*APLASequencer) aplaComponent() {}
+func (*APLASpeech) aplaComponent()    {}
+func (*APLAAudio) aplaComponent()     {}
+func (*APLASilence) aplaComponent()   {}
+
+// NewAPLADocument returns an APLA document playing the provided component.
+func NewAPLADocument(item APLAComponent) *APLADocument {
+	return &APLADocument{Type: "APLA", Version: "0.9", MainTemplate: APLATemplate{Item: item}}
+}
+
+// NewAPLAMixer returns a Mixer playing the items at the same time.
+func NewAPLAMixer(items ...APLAComponent) *APLAMixer {
+	return &APLAMixer{Type: "Mixer", Items: items}
+}
+
+// NewAPLASequencer returns a Sequencer playing the items one after the other.
+func NewAPLASequencer(items ...APLAComponent) *APLASequencer {
+	return &APLASequencer{Type: "Sequencer", Items: items}
+}
+
+// NewAPLASpeech returns a Speech component speaking the plain text.
+func NewAPLASpeech(text string) *APLASpeech {
+	return &APLASpeech{Type: "Speech", ContentType: "PlainText", Content: text}
+}
+
+// NewAPLASpeechSSML returns a Speech component speaking the SSML built by the SSMLTextBuilder.
+func NewAPLASpeechSSML(builder *SSMLTextBuilder) *APLASpeech {
+	return &APLASpeech{Type: "Speech", ContentType: "SSML", Content: builder.Build()}
+}
+
+// NewAPLAAudio returns an Audio component playing the source, which can be an https URL or a
+// "soundbank://" sound library reference.
+func NewAPLAAudio(source string) *APLAAudio {
+	return &APLAAudio{Type: "Audio", Source: source}
+}
+
+// NewAPLASilence returns a Silence component lasting the given number of milliseconds.
+func NewAPLASilence(milliseconds int) *APLASilence {
+	return &APLASilence{Type: "Silence", Duration: milliseconds}
+}
+
+// APLARenderDocumentDirective plays an APLA document as the speech of the response.
+type APLARenderDocumentDirective struct {
+	Type        string                 `json:"type"`
+	Token       string                 `json:"token,omitempty"`
+	Document    interface{}            `json:"document"`
+	Datasources map[string]interface{} `json:"datasources,omitempty"`
+}
+
+// DirectiveType implements Directive.
+func (d *APLARenderDocumentDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RenderAPLADocument adds an APLA RenderDocument directive to the response. The document can be an *APLADocument,
+// an APLLink to a saved document or raw JSON. Output speech set on the same response is spoken before the document.
+func (r *EchoResponse) RenderAPLADocument(token string, document interface{}, datasources map[string]interface{}) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &APLARenderDocumentDirective{
+		Type:        aplaRenderDocument,
+		Token:       token,
+		Document:    document,
+		Datasources: datasources,
+	})
+
+	return r
+}
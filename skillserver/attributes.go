@@ -0,0 +1,209 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AttributesVersionKey is the session attribute used to record the schema version of a struct
+// written with `EchoResponse.SetSessionAttributesFrom`. It should not be used by skill code for any other value.
+const AttributesVersionKey = "_attributesVersion"
+
+// ErrAttributesVersion is returned when the session attributes were written by a newer version of the
+// attribute struct than the one they are being decoded into.
+var ErrAttributesVersion = errors.New("session attributes written by a newer schema version")
+
+// VersionedAttributes can be implemented by a session attribute struct to have its schema version
+// stored alongside the attributes. The version is used on the next request to detect sessions
+// that were started before the struct changed.
+type VersionedAttributes interface {
+	AttributesVersion() int
+}
+
+// AttributesMigrator can be implemented by a versioned session attribute struct to upgrade the raw
+// attributes of an older session before they are decoded into the struct. The attribute map can be
+// modified in place.
+type AttributesMigrator interface {
+	VersionedAttributes
+	MigrateAttributes(fromVersion int, attrs map[string]interface{}) error
+}
+
+// SessionAttributesVersion returns the schema version stored in the session attributes of the request
+// or 0 if no version was stored.
+func (r *EchoRequest) SessionAttributesVersion() int {
+	return attributesVersion(r.Session.Attributes)
+}
+
+// SessionAttributesInto decodes the session attributes of the request into the struct pointed to by v,
+// using the same rules as `json.Unmarshal`. If v implements `VersionedAttributes` the stored version is
+// checked first; attributes from an older version are passed through `MigrateAttributes` when v
+// implements `AttributesMigrator` and are otherwise decoded as they are.
+func (r *EchoRequest) SessionAttributesInto(v interface{}) error {
+	return decodeAttributes(r.Session.Attributes, v)
+}
+
+// SetSessionAttributesFrom encodes the struct v into the session attributes of the response. The
+// attributes named by the fields of v are replaced: fields left out by omitempty are set to null, so
+// that `CarrySessionAttributes` does not bring back their old values. Existing attributes with other
+// names are left in place. If v implements `VersionedAttributes` its version is stored under
+// `AttributesVersionKey`.
+func (r *EchoResponse) SetSessionAttributesFrom(v interface{}) error {
+	attrs, err := encodeAttributes(v)
+	if err != nil {
+		return err
+	}
+
+	if r.SessionAttributes == nil {
+		r.SessionAttributes = make(map[string]interface{})
+	}
+
+	for _, k := range attributeKeys(reflect.TypeOf(v)) {
+		r.SessionAttributes[k] = nil
+	}
+
+	for k, val := range attrs {
+		r.SessionAttributes[k] = val
+	}
+
+	return nil
+}
+
+// CarrySessionAttributes copies the session attributes of the incoming request into the response so
+// they are sent back to the Alexa service unchanged. Attributes already set on the response win.
+func (r *EchoResponse) CarrySessionAttributes(req *EchoRequest) *EchoResponse {
+	if r.SessionAttributes == nil {
+		r.SessionAttributes = make(map[string]interface{})
+	}
+
+	for k, v := range req.Session.Attributes {
+		if _, ok := r.SessionAttributes[k]; !ok {
+			r.SessionAttributes[k] = v
+		}
+	}
+
+	return r
+}
+
+func attributesVersion(attrs map[string]interface{}) int {
+	switch v := attrs[AttributesVersionKey].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case json.Number:
+		i, _ := v.Int64()
+		return int(i)
+	}
+
+	return 0
+}
+
+// versionedAttributes returns v as VersionedAttributes, also when the methods are implemented on a
+// pointer receiver and v was passed by value.
+func versionedAttributes(v interface{}) (VersionedAttributes, bool) {
+	if versioned, ok := v.(VersionedAttributes); ok {
+		return versioned, true
+	}
+
+	if v == nil {
+		return nil, false
+	}
+
+	ptr := reflect.New(reflect.TypeOf(v))
+	ptr.Elem().Set(reflect.ValueOf(v))
+
+	versioned, ok := ptr.Interface().(VersionedAttributes)
+	return versioned, ok
+}
+
+// attributeKeys returns the names of the attributes the fields of a struct type are encoded to,
+// including fields of embedded structs. It returns nil for other types.
+func attributeKeys(t reflect.Type) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			keys = append(keys, attributeKeys(field.Type)...)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		keys = append(keys, name)
+	}
+
+	return keys
+}
+
+func decodeAttributes(attrs map[string]interface{}, v interface{}) error {
+	if versioned, ok := versionedAttributes(v); ok {
+		stored := attributesVersion(attrs)
+		current := versioned.AttributesVersion()
+
+		if stored > current {
+			return ErrAttributesVersion
+		}
+
+		if migrator, ok := versioned.(AttributesMigrator); ok && stored < current {
+			copied := make(map[string]interface{}, len(attrs))
+			for k, val := range attrs {
+				copied[k] = val
+			}
+
+			if err := migrator.MigrateAttributes(stored, copied); err != nil {
+				return fmt.Errorf("could not migrate session attributes from version %d: %s", stored, err)
+			}
+
+			attrs = copied
+		}
+	}
+
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+func encodeAttributes(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return nil, errors.New("session attributes must encode to a JSON object: " + err.Error())
+	}
+
+	if versioned, ok := versionedAttributes(v); ok {
+		attrs[AttributesVersionKey] = versioned.AttributesVersion()
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,189 @@
+package skillserver
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+const sealedAttributePrefix = "sealed:"
+
+// ErrAttributeTampered is returned when a protected session attribute could not be authenticated, which
+// means it was modified, sent in plaintext, or sealed with a key the codec does not know.
+var ErrAttributeTampered = errors.New("protected session attribute failed authentication")
+
+// AttributeCodec encrypts and authenticates selected session attributes with AES-GCM so they can be sent
+// through the Alexa service and the device without being readable or modifiable there. Each sealed value
+// is bound to its attribute name and session, so values can't be moved between attributes or sessions.
+//
+// Keys can be rotated by adding the new key with `AddKey` and making it current with `UseKey`. Values sealed
+// with older keys can be opened as long as those keys stay registered.
+type AttributeCodec struct {
+	mu        sync.RWMutex
+	aeads     map[string]cipher.AEAD
+	currentID string
+	protected map[string]bool
+}
+
+// NewAttributeCodec returns a codec that seals the named attributes with the provided key. The key must
+// be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewAttributeCodec(keyID string, key []byte, attributes ...string) (*AttributeCodec, error) {
+	c := &AttributeCodec{aeads: map[string]cipher.AEAD{}, protected: map[string]bool{}}
+
+	if err := c.AddKey(keyID, key); err != nil {
+		return nil, err
+	}
+
+	c.currentID = keyID
+	c.Protect(attributes...)
+
+	return c, nil
+}
+
+// AddKey registers an additional key that can be used to open sealed attributes.
+func (c *AttributeCodec) AddKey(keyID string, key []byte) error {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return errors.New("key ID must be non-empty and can not contain ':'")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.aeads[keyID] = aead
+	c.mu.Unlock()
+
+	return nil
+}
+
+// UseKey makes a previously added key the one used to seal new values.
+func (c *AttributeCodec) UseKey(keyID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.aeads[keyID]; !ok {
+		return errors.New("unknown key ID: " + keyID)
+	}
+
+	c.currentID = keyID
+
+	return nil
+}
+
+// RemoveKey drops a key once no session can still hold a value sealed with it. The current key can
+// not be removed.
+func (c *AttributeCodec) RemoveKey(keyID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if keyID == c.currentID {
+		return errors.New("can not remove the current key")
+	}
+
+	delete(c.aeads, keyID)
+
+	return nil
+}
+
+// Protect adds attribute names to the set of attributes sealed by the codec.
+func (c *AttributeCodec) Protect(attributes ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range attributes {
+		c.protected[name] = true
+	}
+}
+
+// Seal replaces the value of every protected attribute in attrs with its encrypted form.
+func (c *AttributeCodec) Seal(sessionID string, attrs map[string]interface{}) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	aead := c.aeads[c.currentID]
+
+	for name, value := range attrs {
+		if !c.protected[name] {
+			continue
+		}
+
+		plaintext, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return err
+		}
+
+		sealed := aead.Seal(nonce, nonce, plaintext, attributeAAD(sessionID, name))
+		attrs[name] = sealedAttributePrefix + c.currentID + ":" + base64.RawURLEncoding.EncodeToString(sealed)
+	}
+
+	return nil
+}
+
+// Open decrypts every protected attribute in attrs in place. ErrAttributeTampered is returned if any of them
+// can't be authenticated, in which case attrs should not be trusted at all.
+func (c *AttributeCodec) Open(sessionID string, attrs map[string]interface{}) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for name, value := range attrs {
+		if !c.protected[name] {
+			continue
+		}
+
+		sealed, ok := value.(string)
+		if !ok || !strings.HasPrefix(sealed, sealedAttributePrefix) {
+			return ErrAttributeTampered
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(sealed, sealedAttributePrefix), ":", 2)
+		if len(parts) != 2 {
+			return ErrAttributeTampered
+		}
+
+		aead, ok := c.aeads[parts[0]]
+		if !ok {
+			return ErrAttributeTampered
+		}
+
+		data, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil || len(data) < aead.NonceSize() {
+			return ErrAttributeTampered
+		}
+
+		plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], attributeAAD(sessionID, name))
+		if err != nil {
+			return ErrAttributeTampered
+		}
+
+		var opened interface{}
+		if err := json.Unmarshal(plaintext, &opened); err != nil {
+			return ErrAttributeTampered
+		}
+
+		attrs[name] = opened
+	}
+
+	return nil
+}
+
+func attributeAAD(sessionID, name string) []byte {
+	return []byte(sessionID + "\x00" + name)
+}
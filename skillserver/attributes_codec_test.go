@@ -0,0 +1,134 @@
+package skillserver_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+func newCodec(t *testing.T) *alexa.AttributeCodec {
+	codec, err := alexa.NewAttributeCodec("2026-01", bytes.Repeat([]byte{1}, 32), "cart", "coupon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return codec
+}
+
+func TestAttributeCodecRoundTrip(t *testing.T) {
+	codec := newCodec(t)
+
+	attrs := map[string]interface{}{
+		"cart":  map[string]interface{}{"latte": 2.0},
+		"theme": "dark",
+	}
+
+	if err := codec.Seal("session-1", attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, ok := attrs["cart"].(string)
+	if !ok || !strings.HasPrefix(sealed, "sealed:2026-01:") || strings.Contains(sealed, "latte") {
+		t.Fatalf("sealed cart = %v", attrs["cart"])
+	}
+
+	if attrs["theme"] != "dark" {
+		t.Fatalf("unprotected theme = %v, want it left as it is", attrs["theme"])
+	}
+
+	if err := codec.Open("session-1", attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	if cart, ok := attrs["cart"].(map[string]interface{}); !ok || cart["latte"] != 2.0 {
+		t.Fatalf("opened cart = %v", attrs["cart"])
+	}
+}
+
+func TestAttributeCodecRejectsTampering(t *testing.T) {
+	codec := newCodec(t)
+
+	attrs := map[string]interface{}{"cart": "latte", "coupon": "latte"}
+	if err := codec.Seal("session-1", attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	sealed := attrs["cart"].(string)
+	flipped := []byte(sealed)
+	if i := len(flipped) - 10; flipped[i] == 'A' {
+		flipped[i] = 'B'
+	} else {
+		flipped[i] = 'A'
+	}
+
+	tests := map[string]struct {
+		sessionID string
+		attrs     map[string]interface{}
+	}{
+		"tampered ciphertext":  {"session-1", map[string]interface{}{"cart": string(flipped)}},
+		"moved to coupon":      {"session-1", map[string]interface{}{"coupon": sealed}},
+		"moved to session":     {"session-2", map[string]interface{}{"cart": sealed}},
+		"plaintext value":      {"session-1", map[string]interface{}{"cart": "latte"}},
+		"plaintext non-string": {"session-1", map[string]interface{}{"cart": 2.0}},
+		"unknown key":          {"session-1", map[string]interface{}{"cart": strings.Replace(sealed, "2026-01", "2025-12", 1)}},
+	}
+
+	for name, test := range tests {
+		if err := codec.Open(test.sessionID, test.attrs); err != alexa.ErrAttributeTampered {
+			t.Errorf("%s: Open returned %v, want ErrAttributeTampered", name, err)
+		}
+	}
+}
+
+func TestAttributeCodecKeyRotation(t *testing.T) {
+	codec := newCodec(t)
+
+	old := map[string]interface{}{"cart": "latte"}
+	if err := codec.Seal("session-1", old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := codec.AddKey("2026-07", bytes.Repeat([]byte{2}, 16)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := codec.UseKey("2026-07"); err != nil {
+		t.Fatal(err)
+	}
+
+	current := map[string]interface{}{"cart": "mocha"}
+	if err := codec.Seal("session-1", current); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(current["cart"].(string), "sealed:2026-07:") {
+		t.Fatalf("sealed cart = %v, want it sealed with the new key", current["cart"])
+	}
+
+	stillOpen := map[string]interface{}{"cart": old["cart"]}
+	if err := codec.Open("session-1", stillOpen); err != nil || stillOpen["cart"] != "latte" {
+		t.Fatalf("opening a value sealed with the old key = %v, %v", stillOpen["cart"], err)
+	}
+
+	if err := codec.RemoveKey("2026-07"); err == nil {
+		t.Fatal("RemoveKey removed the current key")
+	}
+
+	if err := codec.RemoveKey("2026-01"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := codec.Open("session-1", map[string]interface{}{"cart": old["cart"]}); err != alexa.ErrAttributeTampered {
+		t.Fatalf("opening a value sealed with a removed key returned %v, want ErrAttributeTampered", err)
+	}
+
+	if err := codec.Open("session-1", current); err != nil || current["cart"] != "mocha" {
+		t.Fatalf("opening a value sealed with the current key = %v, %v", current["cart"], err)
+	}
+
+	if err := codec.UseKey("2026-01"); err == nil {
+		t.Fatal("UseKey accepted a removed key")
+	}
+}
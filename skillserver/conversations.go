@@ -0,0 +1,126 @@
+package skillserver
+
+import (
+	"encoding/json"
+
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+const (
+	// TargetConversations is the Dialog.DelegateRequest target that hands the dialog to Alexa Conversations.
+	TargetConversations = "AMAZON.Conversations"
+
+	// TargetSkill is the Dialog.DelegateRequest target that hands the dialog back to the skill.
+	TargetSkill = "skill"
+)
+
+// EchoAPIRequest holds the API call Alexa Conversations makes to the skill in a Dialog.API.Invoked request.
+type EchoAPIRequest struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+	Slots     map[string]EchoSlot    `json:"slots,omitempty"`
+}
+
+// EchoDelegatePeriod controls how long a Dialog.DelegateRequest target stays in charge of the dialog.
+type EchoDelegatePeriod struct {
+	Until string `json:"until"`
+}
+
+// EchoDelegatedRequest is the request passed to the target of a Dialog.DelegateRequest directive.
+// Alexa Conversations expects a Dialog.InputRequest with an input, the skill expects an IntentRequest.
+type EchoDelegatedRequest struct {
+	Type   string      `json:"type"`
+	Input  *EchoInput  `json:"input,omitempty"`
+	Intent *EchoIntent `json:"intent,omitempty"`
+}
+
+// EchoInput names the Alexa Conversations dialog to start and the slot values it starts with.
+type EchoInput struct {
+	Name  string              `json:"name"`
+	Slots map[string]EchoSlot `json:"slots,omitempty"`
+}
+
+// GetAPIName is a convenience method for getting the name of the API invoked by Alexa Conversations out
+// of a Dialog.API.Invoked request.
+func (r *EchoRequest) GetAPIName() string {
+	if r.Request.APIRequest == nil {
+		return ""
+	}
+
+	return r.Request.APIRequest.Name
+}
+
+// GetAPIArgument returns the named argument of the API invoked by Alexa Conversations.
+func (r *EchoRequest) GetAPIArgument(name string) (interface{}, bool) {
+	if r.Request.APIRequest == nil {
+		return nil, false
+	}
+
+	arg, ok := r.Request.APIRequest.Arguments[name]
+
+	return arg, ok
+}
+
+// APIArgumentsInto decodes the arguments of the API invoked by Alexa Conversations into the struct pointed to by v.
+func (r *EchoRequest) APIArgumentsInto(v interface{}) error {
+	var args map[string]interface{}
+	if r.Request.APIRequest != nil {
+		args = r.Request.APIRequest.Arguments
+	}
+
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// APIResponse sets the value returned to Alexa Conversations for a Dialog.API.Invoked request. The value
+// must match the return type of the API definition.
+func (r *EchoResponse) APIResponse(v interface{}) *EchoResponse {
+	r.Response.APIResponse = v
+
+	return r.EndSession(false)
+}
+
+// ChainToIntent hands the dialog to a different intent of the skill, optionally with slot values already
+// filled in. Alexa continues with the dialog model of that intent. Unlike other delegations, the
+// response can carry output speech, which Alexa speaks before the first prompt of the new intent.
+func (r *EchoResponse) ChainToIntent(name string, slotValues map[string]string) *EchoResponse {
+	intent := &EchoIntent{Name: name, Slots: map[string]EchoSlot{}}
+	for slotName, value := range slotValues {
+		intent.Slots[slotName] = EchoSlot{Name: slotName, Value: value}
+	}
+
+	r.DialogDelegate(intent)
+	r.Response.Directives[len(r.Response.Directives)-1].(*EchoDirective).chained = true
+	return r
+}
+
+// DelegateToConversations hands the dialog to the named Alexa Conversations dialog, passing along any
+// slot values. Alexa Conversations stays in charge until it explicitly returns to the skill.
+func (r *EchoResponse) DelegateToConversations(name string, slotValues map[string]string) *EchoResponse {
+	input := &EchoInput{Name: name, Slots: map[string]EchoSlot{}}
+	for slotName, value := range slotValues {
+		input.Slots[slotName] = EchoSlot{Name: slotName, Value: value}
+	}
+
+	return r.delegateRequest(TargetConversations, &EchoDelegatedRequest{Type: "Dialog.InputRequest", Input: input})
+}
+
+// DelegateToSkill hands the dialog from Alexa Conversations back to the skill as an IntentRequest for the intent.
+func (r *EchoResponse) DelegateToSkill(intent *EchoIntent) *EchoResponse {
+	return r.delegateRequest(TargetSkill, &EchoDelegatedRequest{Type: "IntentRequest", Intent: updatedIntent(intent)})
+}
+
+func (r *EchoResponse) delegateRequest(target string, request *EchoDelegatedRequest) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &EchoDirective{
+		Type:           dialog.DelegateRequest,
+		Target:         target,
+		Period:         &EchoDelegatePeriod{Until: "EXPLICIT_RETURN"},
+		UpdatedRequest: request,
+	})
+
+	return r.EndSession(false)
+}
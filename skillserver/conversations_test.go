@@ -0,0 +1,43 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+func TestChainToIntentWithSpeech(t *testing.T) {
+	app := alexa.EchoApplication{
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			resp.OutputSpeech("Your coffee is on its way.").ChainToIntent("OrderCake", map[string]string{"Flavor": "lemon"})
+		},
+	}
+
+	resp := dialogTurn(t, app, dialog.Completed, nil)
+
+	directive := dialogDirective(t, resp)
+	if directive.Type != dialog.Delegate || directive.UpdatedIntent == nil || directive.UpdatedIntent.Name != "OrderCake" {
+		t.Fatalf("directive = %+v, want a Dialog.Delegate to OrderCake", directive)
+	}
+
+	if directive.UpdatedIntent.Slots["Flavor"].Value != "lemon" {
+		t.Fatalf("slots = %+v, want Flavor lemon", directive.UpdatedIntent.Slots)
+	}
+
+	if speech(resp) != "Your coffee is on its way." {
+		t.Fatalf("speech = %q", speech(resp))
+	}
+}
+
+func TestDelegateWithSpeechRejected(t *testing.T) {
+	same := &alexa.EchoIntent{Name: "OrderCoffee"}
+
+	if err := alexa.NewEchoResponse().OutputSpeech("Hi").DialogDelegate(same).Validate(); err == nil {
+		t.Error("a delegate without chaining was sent with output speech")
+	}
+
+	if err := alexa.NewEchoResponse().Reprompt("Hi").ChainToIntent("OrderCake", nil).Validate(); err == nil {
+		t.Error("a chained delegate was sent with a reprompt")
+	}
+}
@@ -15,6 +15,12 @@ const (
 
 	// ConfirmIntent indicates to the Alexa service that the complete intent should be confimed by the user.
 	ConfirmIntent Type = "Dialog.ConfirmIntent"
+
+	// UpdateDynamicEntities replaces or clears the slot type values that were added for the current user.
+	UpdateDynamicEntities Type = "Dialog.UpdateDynamicEntities"
+
+	// DelegateRequest hands the conversation to Alexa Conversations or back to the skill.
+	DelegateRequest Type = "Dialog.DelegateRequest"
 )
 
 const (
@@ -28,3 +34,14 @@ const (
 	// The intent and slot confirmation status should be checked.
 	Completed string = "COMPLETED"
 )
+
+// UpdateBehavior tells the Alexa service how to apply a Dialog.UpdateDynamicEntities directive.
+type UpdateBehavior string
+
+const (
+	// Replace replaces all dynamic entities of the skill for the current user with the ones in the directive.
+	Replace UpdateBehavior = "REPLACE"
+
+	// Clear removes all dynamic entities of the skill for the current user.
+	Clear UpdateBehavior = "CLEAR"
+)
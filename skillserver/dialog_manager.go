@@ -0,0 +1,262 @@
+package skillserver
+
+import (
+	"strings"
+
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+// DialogSlot declares how a single slot of a DialogIntent should be filled.
+type DialogSlot struct {
+	Name string
+
+	// Required slots are elicited until they have a value.
+	Required bool
+
+	// Prompt and Reprompt are spoken when the slot is elicited. If Prompt is empty the elicitation is
+	// delegated to the prompts configured in the developer console.
+	Prompt   string
+	Reprompt string
+
+	// Validate and then each of Validators are called once the slot has a value. The first error
+	// re-elicits the slot, speaking the error message before the prompt.
+	Validate   SlotValidator
+	Validators []SlotValidator
+
+	// MaxAttempts is the number of invalid values accepted before OnMaxAttempts is called instead of
+	// eliciting the slot again. It defaults to the MaxAttempts of the DialogManager.
+	MaxAttempts   int
+	OnMaxAttempts func(*EchoRequest, *EchoResponse)
+
+	// Confirm asks the user to confirm the value of the slot using ConfirmPrompt. A denied value is
+	// cleared and elicited again. If ConfirmPrompt is empty the confirmation is delegated to the prompts
+	// configured in the developer console.
+	Confirm       bool
+	ConfirmPrompt string
+}
+
+// DialogIntent declares the slots, prompts and confirmation rules of a single intent handled by a
+// DialogManager. Prompts can refer to slot values by wrapping the slot name in braces, e.g. "{City}".
+type DialogIntent struct {
+	Name  string
+	Slots []DialogSlot
+
+	// Confirm asks the user to confirm the whole intent with ConfirmPrompt once all slots are filled. If
+	// ConfirmPrompt is empty the confirmation is delegated to the prompts configured in the developer console.
+	Confirm       bool
+	ConfirmPrompt string
+
+	// Fulfill is called once the dialog is COMPLETED and, if confirmation was requested, CONFIRMED.
+	Fulfill func(*EchoRequest, *EchoResponse)
+
+	// OnDenied is called when the user denies the intent. By default the session is ended.
+	OnDenied func(*EchoRequest, *EchoResponse)
+}
+
+// DialogManager runs the multi-turn slot filling of the intents registered with it. On each turn it
+// emits the Delegate, ElicitSlot, ConfirmSlot or ConfirmIntent directive that moves the dialog forward,
+// and calls the fulfillment handler once the dialog is done.
+type DialogManager struct {
+	intents map[string]*DialogIntent
+
+	// MaxAttempts is the number of invalid values accepted for any slot that doesn't set its own limit.
+	// It defaults to DefaultMaxSlotAttempts.
+	MaxAttempts int
+
+	// Fallback is called when a slot without an OnMaxAttempts handler runs out of attempts. By default
+	// the user is told the value could not be understood and the session is ended.
+	Fallback func(*EchoRequest, *EchoResponse)
+}
+
+// NewDialogManager returns a DialogManager without any intents.
+func NewDialogManager() *DialogManager {
+	return &DialogManager{intents: map[string]*DialogIntent{}}
+}
+
+// Register adds an intent to the manager, replacing any intent registered with the same name.
+func (m *DialogManager) Register(intent DialogIntent) *DialogManager {
+	m.intents[intent.Name] = &intent
+
+	return m
+}
+
+// Handle advances the dialog of the intent in the request and writes the next step to the response.
+// False is returned if the request is not an IntentRequest for a registered intent.
+func (m *DialogManager) Handle(req *EchoRequest, resp *EchoResponse) bool {
+	if req.GetRequestType() != "IntentRequest" {
+		return false
+	}
+
+	intent, ok := m.intents[req.GetIntentName()]
+	if !ok {
+		return false
+	}
+
+	if req.Request.Intent.ConfirmationStatus == ConfDenied {
+		if intent.OnDenied != nil {
+			intent.OnDenied(req, resp)
+		} else {
+			resp.EndSession(true)
+		}
+
+		return true
+	}
+
+	for _, slot := range intent.Slots {
+		if !m.handleSlot(req, resp, slot) {
+			return true
+		}
+	}
+
+	if intent.Confirm && req.Request.Intent.ConfirmationStatus != ConfConfirmed {
+		if intent.ConfirmPrompt == "" {
+			resp.DialogDelegate(copyIntent(req.Request.Intent))
+
+			return true
+		}
+
+		prompt := fillSlotValues(intent.ConfirmPrompt, req)
+		resp.DialogConfirmIntent(copyIntent(req.Request.Intent), prompt, prompt)
+
+		return true
+	}
+
+	if req.Request.DialogState != dialog.Completed {
+		resp.DialogDelegate(copyIntent(req.Request.Intent))
+
+		return true
+	}
+
+	if intent.Fulfill != nil {
+		intent.Fulfill(req, resp)
+	}
+
+	return true
+}
+
+// handleSlot checks a single slot and emits the directive needed to fix it. It returns true when the
+// slot needs nothing more from the user.
+func (m *DialogManager) handleSlot(req *EchoRequest, resp *EchoResponse, slot DialogSlot) bool {
+	value, _ := req.GetSlot(slot.Name)
+
+	if value.Value == "" {
+		if !slot.Required {
+			return true
+		}
+
+		elicitSlot(req, resp, slot, "")
+
+		return false
+	}
+
+	if err := validateSlot(req, slot, value); err != nil {
+		attempts := slotAttempts(req, req.GetIntentName(), slot.Name) + 1
+		if attempts >= m.maxAttempts(slot) {
+			setSlotAttempts(req, resp, req.GetIntentName(), slot.Name, 0)
+			m.fallback(req, resp, slot)
+
+			return false
+		}
+
+		setSlotAttempts(req, resp, req.GetIntentName(), slot.Name, attempts)
+		elicitSlot(req, resp, slot, err.Error())
+
+		return false
+	}
+
+	if slotAttempts(req, req.GetIntentName(), slot.Name) > 0 {
+		setSlotAttempts(req, resp, req.GetIntentName(), slot.Name, 0)
+	}
+
+	if slot.Confirm && value.ConfirmationStatus == ConfDenied {
+		elicitSlot(req, resp, slot, "")
+
+		return false
+	}
+
+	if slot.Confirm && value.ConfirmationStatus != ConfConfirmed {
+		if slot.ConfirmPrompt == "" {
+			resp.DialogDelegate(copyIntent(req.Request.Intent))
+
+			return false
+		}
+
+		prompt := fillSlotValues(slot.ConfirmPrompt, req)
+		resp.DialogConfirmSlot(slot.Name, copyIntent(req.Request.Intent), prompt, prompt)
+
+		return false
+	}
+
+	return true
+}
+
+func (m *DialogManager) maxAttempts(slot DialogSlot) int {
+	if slot.MaxAttempts > 0 {
+		return slot.MaxAttempts
+	}
+
+	if m.MaxAttempts > 0 {
+		return m.MaxAttempts
+	}
+
+	return DefaultMaxSlotAttempts
+}
+
+func (m *DialogManager) fallback(req *EchoRequest, resp *EchoResponse, slot DialogSlot) {
+	if slot.OnMaxAttempts != nil {
+		slot.OnMaxAttempts(req, resp)
+	} else if m.Fallback != nil {
+		m.Fallback(req, resp)
+	} else {
+		resp.OutputSpeech("Sorry, I couldn't understand that. Please try again later.").EndSession(true)
+	}
+}
+
+// elicitSlot asks for the slot again with its value cleared, prefixing the prompt with the reason when one is given.
+func elicitSlot(req *EchoRequest, resp *EchoResponse, slot DialogSlot, reason string) {
+	updated := copyIntent(req.Request.Intent)
+	elicited := updated.Slots[slot.Name]
+	elicited.Name = slot.Name
+	elicited.Value = ""
+	elicited.Resolutions = EchoResolution{}
+	elicited.ConfirmationStatus = ConfNone
+	updated.Slots[slot.Name] = elicited
+
+	if slot.Prompt == "" && reason == "" {
+		resp.DialogDelegate(updated)
+		return
+	}
+
+	prompt := strings.TrimSpace(reason + " " + fillSlotValues(slot.Prompt, req))
+	reprompt := slot.Reprompt
+	if reprompt == "" {
+		reprompt = slot.Prompt
+	}
+
+	if reprompt == "" {
+		reprompt = reason
+	}
+
+	resp.DialogElicitSlot(slot.Name, updated, prompt, fillSlotValues(reprompt, req))
+}
+
+// copyIntent returns a copy of the intent whose slots can be modified without changing the request.
+func copyIntent(intent EchoIntent) *EchoIntent {
+	copied := intent
+	copied.Slots = make(map[string]EchoSlot, len(intent.Slots))
+
+	for name, slot := range intent.Slots {
+		copied.Slots[name] = slot
+	}
+
+	return &copied
+}
+
+// fillSlotValues replaces every "{SlotName}" in the prompt with the value of that slot.
+func fillSlotValues(prompt string, req *EchoRequest) string {
+	for name, slot := range req.AllSlots() {
+		prompt = strings.Replace(prompt, "{"+name+"}", slot.Value, -1)
+	}
+
+	return prompt
+}
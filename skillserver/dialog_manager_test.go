@@ -0,0 +1,241 @@
+package skillserver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+// dialogTurn sends an IntentRequest for the OrderCoffee intent with the slot values and session attributes
+// through the application and returns the decoded response.
+func dialogTurn(t *testing.T, app alexa.EchoApplication, state string, attrs map[string]interface{}, slots ...alexa.EchoSlot) *alexa.EchoResponse {
+	req := &alexa.EchoRequest{}
+	req.Session.SessionID = "amzn1.echo-api.session.test"
+	req.Session.Attributes = attrs
+	req.Request.Type = "IntentRequest"
+	req.Request.DialogState = state
+	req.Request.Intent.Name = "OrderCoffee"
+	req.Request.Intent.ConfirmationStatus = alexa.ConfNone
+	req.Request.Intent.Slots = map[string]alexa.EchoSlot{}
+
+	for _, slot := range slots {
+		req.Request.Intent.Slots[slot.Name] = slot
+	}
+
+	w := alexa.ServeEcho(app, req)
+	if w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	resp := &alexa.EchoResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp
+}
+
+// dialogDirective returns the only dialog directive of the response.
+func dialogDirective(t *testing.T, resp *alexa.EchoResponse) *alexa.EchoDirective {
+	if len(resp.Response.Directives) != 1 {
+		t.Fatalf("%d directives, want 1", len(resp.Response.Directives))
+	}
+
+	directive, ok := resp.Response.Directives[0].(*alexa.EchoDirective)
+	if !ok {
+		t.Fatalf("directive = %T, want a dialog directive", resp.Response.Directives[0])
+	}
+
+	return directive
+}
+
+func speech(resp *alexa.EchoResponse) string {
+	if resp.Response.OutputSpeech == nil {
+		return ""
+	}
+
+	return resp.Response.OutputSpeech.Text
+}
+
+func TestDialogManagerElicitsRequiredSlot(t *testing.T) {
+	manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+		Name:  "OrderCoffee",
+		Slots: []alexa.DialogSlot{{Name: "Size", Required: true, Prompt: "What size?"}},
+	})
+
+	resp := dialogTurn(t, alexa.EchoApplication{Dialogs: manager}, dialog.Started, nil, alexa.EchoSlot{Name: "Size"})
+
+	directive := dialogDirective(t, resp)
+	if directive.Type != dialog.ElicitSlot || directive.SlotToElicit != "Size" {
+		t.Fatalf("directive = %+v, want ElicitSlot for Size", directive)
+	}
+
+	if speech(resp) != "What size?" || resp.Response.Reprompt.OutputSpeech.Text != "What size?" {
+		t.Fatalf("speech = %q, reprompt = %+v", speech(resp), resp.Response.Reprompt)
+	}
+
+	if resp.Response.ShouldEndSession {
+		t.Fatal("eliciting a slot ended the session")
+	}
+}
+
+func TestDialogManagerDelegatesWithoutPrompt(t *testing.T) {
+	manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+		Name:  "OrderCoffee",
+		Slots: []alexa.DialogSlot{{Name: "Size", Required: true}},
+	})
+
+	resp := dialogTurn(t, alexa.EchoApplication{Dialogs: manager}, dialog.Started, nil, alexa.EchoSlot{Name: "Size"})
+
+	if directive := dialogDirective(t, resp); directive.Type != dialog.Delegate {
+		t.Fatalf("directive = %+v, want Delegate", directive)
+	}
+}
+
+func TestDialogManagerReelicitsInvalidValue(t *testing.T) {
+	fallbacks := 0
+	manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+		Name: "OrderCoffee",
+		Slots: []alexa.DialogSlot{{
+			Name:        "Size",
+			Required:    true,
+			Validate:    alexa.OneOf("We only have small and large.", "small", "large"),
+			MaxAttempts: 3,
+		}},
+	})
+	manager.Fallback = func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+		fallbacks++
+		resp.OutputSpeech("Let's try again later.").EndSession(true)
+	}
+
+	app := alexa.EchoApplication{Dialogs: manager}
+	size := alexa.EchoSlot{Name: "Size", Value: "huge"}
+
+	var attrs map[string]interface{}
+	for attempt := 1; attempt < 3; attempt++ {
+		resp := dialogTurn(t, app, dialog.InProgress, attrs, size)
+
+		directive := dialogDirective(t, resp)
+		if directive.Type != dialog.ElicitSlot || directive.UpdatedIntent.Slots["Size"].Value != "" {
+			t.Fatalf("attempt %d: directive = %+v, want ElicitSlot with the value cleared", attempt, directive)
+		}
+
+		// Without a prompt of its own, the reason is both spoken and used as the reprompt.
+		if speech(resp) != "We only have small and large." || resp.Response.Reprompt.OutputSpeech.Text != "We only have small and large." {
+			t.Fatalf("attempt %d: speech = %q, reprompt = %+v", attempt, speech(resp), resp.Response.Reprompt)
+		}
+
+		counts, _ := resp.SessionAttributes["_slotAttempts"].(map[string]interface{})
+		if counts["OrderCoffee.Size"] != float64(attempt) {
+			t.Fatalf("attempt %d: attempt counts = %v", attempt, counts)
+		}
+
+		attrs = resp.SessionAttributes
+	}
+
+	resp := dialogTurn(t, app, dialog.InProgress, attrs, size)
+	if fallbacks != 1 || speech(resp) != "Let's try again later." || !resp.Response.ShouldEndSession {
+		t.Fatalf("after the last attempt: %d fallbacks, speech %q", fallbacks, speech(resp))
+	}
+
+	if _, ok := resp.SessionAttributes["_slotAttempts"]; ok {
+		t.Fatalf("attempt counts kept after the fallback: %v", resp.SessionAttributes)
+	}
+}
+
+func TestDialogManagerSlotMaxAttemptsHandler(t *testing.T) {
+	called := false
+	manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+		Name: "OrderCoffee",
+		Slots: []alexa.DialogSlot{{
+			Name:          "Size",
+			Required:      true,
+			Validate:      alexa.OneOf("We only have small and large.", "small", "large"),
+			MaxAttempts:   1,
+			OnMaxAttempts: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) { called = true },
+		}},
+	})
+
+	dialogTurn(t, alexa.EchoApplication{Dialogs: manager}, dialog.InProgress, nil, alexa.EchoSlot{Name: "Size", Value: "huge"})
+
+	if !called {
+		t.Fatal("OnMaxAttempts of the slot was not called")
+	}
+}
+
+func TestDialogManagerConfirmsSlot(t *testing.T) {
+	register := func(prompt string) alexa.EchoApplication {
+		manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+			Name:  "OrderCoffee",
+			Slots: []alexa.DialogSlot{{Name: "Size", Required: true, Confirm: true, ConfirmPrompt: prompt}},
+		})
+
+		return alexa.EchoApplication{Dialogs: manager}
+	}
+
+	resp := dialogTurn(t, register("A {Size} coffee?"), dialog.InProgress, nil, alexa.EchoSlot{Name: "Size", Value: "large"})
+
+	directive := dialogDirective(t, resp)
+	if directive.Type != dialog.ConfirmSlot || directive.SlotToConfirm != "Size" || speech(resp) != "A large coffee?" {
+		t.Fatalf("directive = %+v, speech = %q, want ConfirmSlot for Size", directive, speech(resp))
+	}
+
+	resp = dialogTurn(t, register(""), dialog.InProgress, nil, alexa.EchoSlot{Name: "Size", Value: "large"})
+	if directive := dialogDirective(t, resp); directive.Type != dialog.Delegate {
+		t.Fatalf("directive without a confirm prompt = %+v, want Delegate", directive)
+	}
+
+	denied := alexa.EchoSlot{Name: "Size", Value: "large", ConfirmationStatus: alexa.ConfDenied}
+	resp = dialogTurn(t, register("A {Size} coffee?"), dialog.InProgress, nil, denied)
+	if directive := dialogDirective(t, resp); directive.Type != dialog.Delegate || directive.UpdatedIntent.Slots["Size"].Value != "" {
+		t.Fatalf("directive for a denied value = %+v, want the slot elicited again", directive)
+	}
+}
+
+func TestDialogManagerConfirmsIntent(t *testing.T) {
+	fulfilled := false
+	register := func(prompt string) alexa.EchoApplication {
+		manager := alexa.NewDialogManager().Register(alexa.DialogIntent{
+			Name:          "OrderCoffee",
+			Slots:         []alexa.DialogSlot{{Name: "Size", Required: true}},
+			Confirm:       true,
+			ConfirmPrompt: prompt,
+			Fulfill: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+				fulfilled = true
+				resp.OutputSpeech("Coming up.")
+			},
+		})
+
+		return alexa.EchoApplication{Dialogs: manager}
+	}
+
+	size := alexa.EchoSlot{Name: "Size", Value: "small"}
+
+	resp := dialogTurn(t, register("Order a {Size} coffee?"), dialog.InProgress, nil, size)
+	directive := dialogDirective(t, resp)
+	if directive.Type != dialog.ConfirmIntent || speech(resp) != "Order a small coffee?" {
+		t.Fatalf("directive = %+v, speech = %q, want ConfirmIntent", directive, speech(resp))
+	}
+
+	resp = dialogTurn(t, register(""), dialog.InProgress, nil, size)
+	if directive := dialogDirective(t, resp); directive.Type != dialog.Delegate {
+		t.Fatalf("directive without a confirm prompt = %+v, want Delegate", directive)
+	}
+
+	if fulfilled {
+		t.Fatal("intent fulfilled before it was confirmed")
+	}
+
+	req := &alexa.EchoRequest{}
+	req.Request.Type = "IntentRequest"
+	req.Request.DialogState = dialog.Completed
+	req.Request.Intent.Name = "OrderCoffee"
+	req.Request.Intent.ConfirmationStatus = alexa.ConfConfirmed
+	req.Request.Intent.Slots = map[string]alexa.EchoSlot{"Size": size}
+
+	if w := alexa.ServeEcho(register("Order a {Size} coffee?"), req); w.Code != 200 || !fulfilled {
+		t.Fatalf("confirmed intent: status %d, fulfilled %v", w.Code, fulfilled)
+	}
+}
@@ -0,0 +1,243 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+// Directive is implemented by everything that can be sent in the directives of an EchoResponse.
+// Directives are marshaled to JSON as they are, so the type needs to produce the full directive object.
+type Directive interface {
+	DirectiveType() string
+}
+
+// InterfaceDirective is implemented by directives that only work on devices supporting a specific
+// interface, such as Alexa.Presentation.APL. They are removed from responses to devices without it.
+type InterfaceDirective interface {
+	Directive
+	RequiredInterface() string
+}
+
+// AudioPlayerInterface is the supported interface name of devices that can play long form audio.
+const AudioPlayerInterface = "AudioPlayer"
+
+var (
+	directiveTypesMu sync.RWMutex
+	directiveTypes   = map[string]func() Directive{}
+)
+
+func init() {
+	for _, t := range []dialog.Type{dialog.Delegate, dialog.ElicitSlot, dialog.ConfirmSlot, dialog.ConfirmIntent,
+		dialog.UpdateDynamicEntities, dialog.DelegateRequest} {
+		RegisterDirective(string(t), func() Directive { return &EchoDirective{} })
+	}
+
+	RegisterDirective(aplRenderDocument, func() Directive { return &APLRenderDocumentDirective{} })
+	RegisterDirective(aplExecuteCommands, func() Directive { return &APLExecuteCommandsDirective{} })
+	RegisterDirective(aplaRenderDocument, func() Directive { return &APLARenderDocumentDirective{} })
+	RegisterDirective("Display.RenderTemplate", func() Directive { return &DisplayRenderTemplateDirective{} })
+	RegisterDirective("VideoApp.Launch", func() Directive { return &VideoAppLaunchDirective{} })
+	RegisterDirective("AudioPlayer.Play", func() Directive { return &AudioPlayerPlayDirective{} })
+	RegisterDirective("AudioPlayer.Stop", func() Directive { return &AudioPlayerDirective{} })
+	RegisterDirective("AudioPlayer.ClearQueue", func() Directive { return &AudioPlayerDirective{} })
+	RegisterDirective("Hint", func() Directive { return &HintDirective{} })
+	RegisterDirective("Connections.SendRequest", func() Directive { return &ConnectionsSendRequestDirective{} })
+}
+
+// RegisterDirective makes UnmarshalDirective decode directives of the given type into the value returned
+// by newDirective, which must be a pointer. Directives of types that were never registered are decoded
+// as a *RawDirective.
+func RegisterDirective(directiveType string, newDirective func() Directive) {
+	directiveTypesMu.Lock()
+	defer directiveTypesMu.Unlock()
+
+	directiveTypes[directiveType] = newDirective
+}
+
+// UnmarshalDirective decodes a single directive object into the type registered for its "type".
+func UnmarshalDirective(data []byte) (Directive, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Type == "" {
+		return nil, errors.New("directive has no type")
+	}
+
+	directiveTypesMu.RLock()
+	newDirective, ok := directiveTypes[header.Type]
+	directiveTypesMu.RUnlock()
+
+	if !ok {
+		return &RawDirective{Type: header.Type, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+
+	directive := newDirective()
+	if err := json.Unmarshal(data, directive); err != nil {
+		return nil, err
+	}
+
+	return directive, nil
+}
+
+// UnmarshalJSON decodes the response body, including each of its directives through UnmarshalDirective.
+func (b *EchoRespBody) UnmarshalJSON(data []byte) error {
+	type body EchoRespBody
+
+	var raw struct {
+		body
+		Directives []json.RawMessage `json:"directives,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*b = EchoRespBody(raw.body)
+	b.Directives = nil
+
+	for _, data := range raw.Directives {
+		directive, err := UnmarshalDirective(data)
+		if err != nil {
+			return err
+		}
+
+		b.Directives = append(b.Directives, directive)
+	}
+
+	return nil
+}
+
+// AddDirective adds any directive to the response. This can be used for directive types the library
+// doesn't have a builder for, either with a custom type or a RawDirective.
+func (r *EchoResponse) AddDirective(directive Directive) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, directive)
+
+	return r
+}
+
+// RawDirective passes a directive through as raw JSON. It is used for directive types the library doesn't
+// know yet, both when building and when decoding responses.
+type RawDirective struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+// NewRawDirective wraps a JSON directive object, which must have a "type".
+func NewRawDirective(data []byte) (*RawDirective, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Type == "" {
+		return nil, errors.New("directive has no type")
+	}
+
+	return &RawDirective{Type: header.Type, Raw: append(json.RawMessage(nil), data...)}, nil
+}
+
+// DirectiveType implements Directive.
+func (d *RawDirective) DirectiveType() string {
+	return d.Type
+}
+
+// MarshalJSON writes the raw directive as it is, or only its type when Raw is empty.
+func (d *RawDirective) MarshalJSON() ([]byte, error) {
+	if len(d.Raw) == 0 {
+		return json.Marshal(map[string]string{"type": d.Type})
+	}
+
+	return d.Raw, nil
+}
+
+// AudioPlayerPlayDirective starts or enqueues playback of an audio stream.
+type AudioPlayerPlayDirective struct {
+	Type         string `json:"type"`
+	PlayBehavior string `json:"playBehavior"`
+	AudioItem    struct {
+		Stream AudioStream `json:"stream"`
+	} `json:"audioItem"`
+}
+
+// AudioStream is the stream played by an AudioPlayer.Play directive. The token identifies the stream
+// in later AudioPlayer requests.
+type AudioStream struct {
+	URL                   string `json:"url"`
+	Token                 string `json:"token"`
+	ExpectedPreviousToken string `json:"expectedPreviousToken,omitempty"`
+	OffsetInMilliseconds  int    `json:"offsetInMilliseconds"`
+}
+
+// DirectiveType implements Directive.
+func (d *AudioPlayerPlayDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *AudioPlayerPlayDirective) RequiredInterface() string {
+	return AudioPlayerInterface
+}
+
+// AudioPlayerDirective is an AudioPlayer.Stop or AudioPlayer.ClearQueue directive. Only ClearQueue uses
+// the clear behavior.
+type AudioPlayerDirective struct {
+	Type          string `json:"type"`
+	ClearBehavior string `json:"clearBehavior,omitempty"`
+}
+
+// DirectiveType implements Directive.
+func (d *AudioPlayerDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *AudioPlayerDirective) RequiredInterface() string {
+	return AudioPlayerInterface
+}
+
+// HintDirective shows a suggested utterance on devices with a screen.
+type HintDirective struct {
+	Type string      `json:"type"`
+	Hint DisplayText `json:"hint"`
+}
+
+// DirectiveType implements Directive.
+func (d *HintDirective) DirectiveType() string {
+	return d.Type
+}
+
+// PlayAudio adds an AudioPlayer.Play directive for the stream. The play behavior is one of REPLACE_ALL,
+// ENQUEUE or REPLACE_ENQUEUED; an ENQUEUE needs the token of the stream it follows as expectedPreviousToken.
+func (r *EchoResponse) PlayAudio(playBehavior string, stream AudioStream) *EchoResponse {
+	directive := &AudioPlayerPlayDirective{Type: "AudioPlayer.Play", PlayBehavior: playBehavior}
+	directive.AudioItem.Stream = stream
+
+	return r.AddDirective(directive)
+}
+
+// StopAudio adds an AudioPlayer.Stop directive.
+func (r *EchoResponse) StopAudio() *EchoResponse {
+	return r.AddDirective(&AudioPlayerDirective{Type: "AudioPlayer.Stop"})
+}
+
+// ClearAudioQueue adds an AudioPlayer.ClearQueue directive. The clear behavior is CLEAR_ENQUEUED to keep
+// the current stream playing or CLEAR_ALL to stop it as well.
+func (r *EchoResponse) ClearAudioQueue(clearBehavior string) *EchoResponse {
+	return r.AddDirective(&AudioPlayerDirective{Type: "AudioPlayer.ClearQueue", ClearBehavior: clearBehavior})
+}
+
+// Hint adds a Hint directive suggesting an utterance to the user, shown as "Try, Alexa, <text>".
+func (r *EchoResponse) Hint(text string) *EchoResponse {
+	return r.AddDirective(&HintDirective{Type: "Hint", Hint: *PlainText(text)})
+}
@@ -0,0 +1,223 @@
+package skillserver
+
+// Details about the Display interface of the first generation Echo Show and Echo Spot can be found on this page:
+// https://developer.amazon.com/docs/custom-skills/display-interface-reference.html
+
+const (
+	// DisplayInterface is the supported interface name of devices that can render display templates.
+	DisplayInterface = "Display"
+
+	// VideoAppInterface is the supported interface name of devices that can play video.
+	VideoAppInterface = "VideoApp"
+)
+
+// DisplayTemplateType selects the layout of a display template.
+type DisplayTemplateType string
+
+const (
+	// BodyTemplate1 shows a title, text and an optional background image.
+	BodyTemplate1 DisplayTemplateType = "BodyTemplate1"
+	// BodyTemplate2 shows an image on the side with text next to it.
+	BodyTemplate2 DisplayTemplateType = "BodyTemplate2"
+	// BodyTemplate3 shows an image on the left with text to the right of it.
+	BodyTemplate3 DisplayTemplateType = "BodyTemplate3"
+	// BodyTemplate6 shows text over a full screen background image.
+	BodyTemplate6 DisplayTemplateType = "BodyTemplate6"
+	// BodyTemplate7 shows a single scaled image over a background.
+	BodyTemplate7 DisplayTemplateType = "BodyTemplate7"
+	// ListTemplate1 shows a vertical list of text items with optional images.
+	ListTemplate1 DisplayTemplateType = "ListTemplate1"
+	// ListTemplate2 shows a horizontal list of image items with text.
+	ListTemplate2 DisplayTemplateType = "ListTemplate2"
+)
+
+// DisplayTemplate is the content of a Display.RenderTemplate directive. Which fields are shown depends
+// on the template type.
+type DisplayTemplate struct {
+	Type            DisplayTemplateType `json:"type"`
+	Token           string              `json:"token,omitempty"`
+	BackButton      string              `json:"backButton,omitempty"`
+	BackgroundImage *DisplayImage       `json:"backgroundImage,omitempty"`
+	Title           string              `json:"title,omitempty"`
+	Image           *DisplayImage       `json:"image,omitempty"`
+	TextContent     *DisplayTextContent `json:"textContent,omitempty"`
+	ListItems       []DisplayListItem   `json:"listItems,omitempty"`
+}
+
+// DisplayImage is an image shown in a display template, with one or more sources for different screen sizes.
+type DisplayImage struct {
+	ContentDescription string               `json:"contentDescription,omitempty"`
+	Sources            []DisplayImageSource `json:"sources"`
+}
+
+// DisplayImageSource is a single source of a DisplayImage. Size is one of X_SMALL, SMALL, MEDIUM, LARGE or X_LARGE.
+type DisplayImageSource struct {
+	URL          string `json:"url"`
+	Size         string `json:"size,omitempty"`
+	WidthPixels  int    `json:"widthPixels,omitempty"`
+	HeightPixels int    `json:"heightPixels,omitempty"`
+}
+
+// DisplayText is a piece of text in a display template. RichText can contain the markup described in the
+// Display interface reference, such as <b> or <font size="7">.
+type DisplayText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// DisplayTextContent holds up to three lines of text of a template or list item.
+type DisplayTextContent struct {
+	PrimaryText   *DisplayText `json:"primaryText,omitempty"`
+	SecondaryText *DisplayText `json:"secondaryText,omitempty"`
+	TertiaryText  *DisplayText `json:"tertiaryText,omitempty"`
+}
+
+// DisplayListItem is a single selectable item of a list template. Its token is sent back in the
+// Display.ElementSelected request when the user selects it.
+type DisplayListItem struct {
+	Token       string              `json:"token"`
+	Image       *DisplayImage       `json:"image,omitempty"`
+	TextContent *DisplayTextContent `json:"textContent,omitempty"`
+}
+
+// NewDisplayTemplate returns an empty template of the given type.
+func NewDisplayTemplate(templateType DisplayTemplateType, token string) *DisplayTemplate {
+	return &DisplayTemplate{Type: templateType, Token: token}
+}
+
+// NewDisplayImage returns an image with a single source.
+func NewDisplayImage(description, url string) *DisplayImage {
+	return &DisplayImage{ContentDescription: description, Sources: []DisplayImageSource{{URL: url}}}
+}
+
+// PlainText returns text that is shown as it is.
+func PlainText(text string) *DisplayText {
+	return &DisplayText{Type: "PlainText", Text: text}
+}
+
+// RichText returns text that can contain display markup.
+func RichText(text string) *DisplayText {
+	return &DisplayText{Type: "RichText", Text: text}
+}
+
+// SetTitle sets the title shown at the top of the template.
+func (t *DisplayTemplate) SetTitle(title string) *DisplayTemplate {
+	t.Title = title
+	return t
+}
+
+// SetImage sets the main image of the template.
+func (t *DisplayTemplate) SetImage(image *DisplayImage) *DisplayTemplate {
+	t.Image = image
+	return t
+}
+
+// SetBackgroundImage sets the image shown behind the template.
+func (t *DisplayTemplate) SetBackgroundImage(image *DisplayImage) *DisplayTemplate {
+	t.BackgroundImage = image
+	return t
+}
+
+// SetTextContent sets the lines of text of the template. Lines that are not needed can be nil.
+func (t *DisplayTemplate) SetTextContent(primary, secondary, tertiary *DisplayText) *DisplayTemplate {
+	t.TextContent = &DisplayTextContent{PrimaryText: primary, SecondaryText: secondary, TertiaryText: tertiary}
+	return t
+}
+
+// HideBackButton hides the back button of the template, which is visible by default.
+func (t *DisplayTemplate) HideBackButton() *DisplayTemplate {
+	t.BackButton = "HIDDEN"
+	return t
+}
+
+// AddListItem adds an item to a list template.
+func (t *DisplayTemplate) AddListItem(token string, image *DisplayImage, primary, secondary, tertiary *DisplayText) *DisplayTemplate {
+	t.ListItems = append(t.ListItems, DisplayListItem{
+		Token:       token,
+		Image:       image,
+		TextContent: &DisplayTextContent{PrimaryText: primary, SecondaryText: secondary, TertiaryText: tertiary},
+	})
+	return t
+}
+
+// DisplayRenderTemplateDirective shows a display template on first generation screen devices.
+type DisplayRenderTemplateDirective struct {
+	Type     string           `json:"type"`
+	Template *DisplayTemplate `json:"template"`
+}
+
+// DirectiveType implements Directive.
+func (d *DisplayRenderTemplateDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *DisplayRenderTemplateDirective) RequiredInterface() string {
+	return DisplayInterface
+}
+
+// VideoAppLaunchDirective starts playing a video on devices with a screen. A response with this directive
+// is sent without shouldEndSession, as the session always ends when the video starts.
+type VideoAppLaunchDirective struct {
+	Type      string       `json:"type"`
+	VideoItem VideoAppItem `json:"videoItem"`
+}
+
+// VideoAppItem is the video played by a VideoApp.Launch directive.
+type VideoAppItem struct {
+	Source   string                `json:"source"`
+	Metadata *VideoAppItemMetadata `json:"metadata,omitempty"`
+}
+
+// VideoAppItemMetadata is shown while the video is loading.
+type VideoAppItemMetadata struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// DirectiveType implements Directive.
+func (d *VideoAppLaunchDirective) DirectiveType() string {
+	return d.Type
+}
+
+// RequiredInterface implements InterfaceDirective.
+func (d *VideoAppLaunchDirective) RequiredInterface() string {
+	return VideoAppInterface
+}
+
+// RenderTemplate adds a Display.RenderTemplate directive showing the template to the response. The directive
+// is dropped for devices that don't support the Display interface.
+func (r *EchoResponse) RenderTemplate(template *DisplayTemplate) *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &DisplayRenderTemplateDirective{
+		Type:     "Display.RenderTemplate",
+		Template: template,
+	})
+
+	return r
+}
+
+// LaunchVideo adds a VideoApp.Launch directive playing the video at the source URL. The title and subtitle
+// are optional. The directive is dropped for devices that don't support the VideoApp interface.
+func (r *EchoResponse) LaunchVideo(source, title, subtitle string) *EchoResponse {
+	directive := &VideoAppLaunchDirective{Type: "VideoApp.Launch"}
+	directive.VideoItem.Source = source
+
+	if title != "" || subtitle != "" {
+		directive.VideoItem.Metadata = &VideoAppItemMetadata{Title: title, Subtitle: subtitle}
+	}
+
+	r.Response.Directives = append(r.Response.Directives, directive)
+
+	return r
+}
+
+// launchesVideo reports whether the response body contains a VideoApp.Launch directive.
+func (b EchoRespBody) launchesVideo() bool {
+	for _, directive := range b.Directives {
+		if _, ok := directive.(*VideoAppLaunchDirective); ok {
+			return true
+		}
+	}
+
+	return false
+}
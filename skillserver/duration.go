@@ -0,0 +1,73 @@
+package skillserver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoDurationPattern matches the ISO-8601 durations sent in AMAZON.DURATION slots, such as "PT10M" or "P1DT2H".
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses an ISO-8601 duration such as "PT1H30M". Years and months are rejected, as their
+// length depends on the date they are counted from.
+func ParseDuration(value string) (time.Duration, error) {
+	match := isoDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("%q is not an ISO-8601 duration without years or months", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+
+		d += time.Duration(n * float64(unit))
+	}
+
+	return d, nil
+}
+
+// FormatDuration formats the duration as an ISO-8601 duration in hours, minutes and seconds, such as "PT1H30M".
+// Fractions of a second are dropped.
+func FormatDuration(d time.Duration) string {
+	d -= d % time.Second
+	if d <= 0 {
+		return "PT0S"
+	}
+
+	s := "PT"
+	if h := d / time.Hour; h > 0 {
+		s += strconv.Itoa(int(h)) + "H"
+	}
+
+	if m := d % time.Hour / time.Minute; m > 0 {
+		s += strconv.Itoa(int(m)) + "M"
+	}
+
+	if sec := d % time.Minute / time.Second; sec > 0 {
+		s += strconv.Itoa(int(sec)) + "S"
+	}
+
+	return s
+}
+
+// GetSlotDuration is a convenience method for getting the value of an AMAZON.DURATION slot as a time.Duration.
+func (r *EchoRequest) GetSlotDuration(slotName string) (time.Duration, error) {
+	value, err := r.GetSlotValue(slotName)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseDuration(value)
+}
@@ -0,0 +1,52 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+func TestParseDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"PT10M":  10 * time.Minute,
+		"P1DT2H": 26 * time.Hour,
+		"P1W":    7 * 24 * time.Hour,
+		"PT1.5S": 1500 * time.Millisecond,
+		"PT0S":   0,
+	}
+
+	for value, want := range valid {
+		if d, err := alexa.ParseDuration(value); err != nil || d != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", value, d, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "P1M", "P1Y", "10M", "PT-5M"} {
+		if _, err := alexa.ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q) accepted an invalid duration", value)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		90*time.Minute + 5*time.Second: "PT1H30M5S",
+		26 * time.Hour:                 "PT26H",
+		1500 * time.Millisecond:        "PT1S",
+		500 * time.Millisecond:         "PT0S",
+		-time.Minute:                   "PT0S",
+	}
+
+	for d, want := range tests {
+		if got := alexa.FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+
+		if d >= time.Second {
+			if parsed, _ := alexa.ParseDuration(want); parsed != d-d%time.Second {
+				t.Errorf("%q parses to %v, want %v", want, parsed, d-d%time.Second)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package skillserver
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/mikeflynn/go-alexa/skillserver/dialog"
@@ -22,19 +23,45 @@ const (
 	ConfNone ConfirmationStatus = "NONE"
 )
 
+// SessionEndedReason describes why the Alexa service sent a SessionEndedRequest.
+type SessionEndedReason string
+
+const (
+	// ReasonUserInitiated means the user explicitly ended the session.
+	ReasonUserInitiated SessionEndedReason = "USER_INITIATED"
+
+	// ReasonError means an error occurred that caused the session to end. The details can be found
+	// in the `Error` field of the request body.
+	ReasonError SessionEndedReason = "ERROR"
+
+	// ReasonExceededMaxReprompts means the user did not respond or responded with an utterance that
+	// did not match any of the intents of the skill.
+	ReasonExceededMaxReprompts SessionEndedReason = "EXCEEDED_MAX_REPROMPTS"
+)
+
 // Request Functions
 
 // VerifyTimestamp will parse the timestamp in the EchoRequest and verify that it is in the correct
 // format and is not too old. True will be returned if the timestamp is valid; false otherwise.
+// AlexaSkillEvent requests may be delivered up to an hour after they happened and are accepted
+// for that long; all other requests must be less than 150 seconds old.
 func (r *EchoRequest) VerifyTimestamp() bool {
 	reqTimestamp, _ := time.Parse("2006-01-02T15:04:05Z", r.Request.Timestamp)
-	if time.Since(reqTimestamp) < time.Duration(150)*time.Second {
+	if time.Since(reqTimestamp) < r.timestampTolerance() {
 		return true
 	}
 
 	return false
 }
 
+func (r *EchoRequest) timestampTolerance() time.Duration {
+	if strings.HasPrefix(r.GetRequestType(), "AlexaSkillEvent.") {
+		return time.Hour
+	}
+
+	return time.Duration(150) * time.Second
+}
+
 // VerifyAppID check that the incoming application ID matches the application ID provided
 // when running the server. This is a step required for skill certification.
 func (r *EchoRequest) VerifyAppID(myAppID string) bool {
@@ -52,8 +79,19 @@ func (r *EchoRequest) GetSessionID() string {
 }
 
 // GetUserID is a convenience method for getting the user identifier out of an EchoRequest.
+// Requests sent outside of a session only carry the user in the context, which is used as a fallback.
 func (r *EchoRequest) GetUserID() string {
-	return r.Session.User.UserID
+	if r.Session.User.UserID != "" {
+		return r.Session.User.UserID
+	}
+
+	return r.Context.System.User.UserID
+}
+
+// GetPersonID is a convenience method for getting the identifier of the recognized speaker out of an
+// EchoRequest. An empty string is returned if the speaker was not recognized.
+func (r *EchoRequest) GetPersonID() string {
+	return r.Context.System.Person.PersonID
 }
 
 // GetRequestType is a convenience method for getting the request type out of an EchoRequest.
@@ -96,6 +134,25 @@ func (r *EchoRequest) AllSlots() map[string]EchoSlot {
 	return r.Request.Intent.Slots
 }
 
+// GetSessionEndedReason is a convenience method for getting the reason a session was ended out of a
+// SessionEndedRequest. An empty reason is returned for any other type of request.
+func (r *EchoRequest) GetSessionEndedReason() SessionEndedReason {
+	return SessionEndedReason(r.Request.Reason)
+}
+
+// GetSessionEndedError returns the error details sent with a SessionEndedRequest, or nil if the session
+// did not end because of an error.
+func (r *EchoRequest) GetSessionEndedError() *EchoReqError {
+	return r.Request.Error
+}
+
+// SupportsInterface reports whether the device the request was sent from supports the named interface,
+// such as "Alexa.Presentation.APL" or "AudioPlayer".
+func (r *EchoRequest) SupportsInterface(name string) bool {
+	_, ok := r.Context.System.Device.SupportedInterfaces[name]
+	return ok
+}
+
 // Locale returns the locale specified in the request.
 func (r *EchoRequest) Locale() string {
 	return r.Request.Locale
@@ -188,6 +245,23 @@ func (r *EchoResponse) LinkAccountCard() *EchoResponse {
 	return r
 }
 
+// AskForPermissionsConsentCard will show a card in the user's companion app asking them to grant the skill
+// the listed permission scopes, such as "read::alexa:device:all:address".
+func (r *EchoResponse) AskForPermissionsConsentCard(scopes ...string) *EchoResponse {
+	r.Response.Card = &EchoRespPayload{
+		Type:        "AskForPermissionsConsent",
+		Permissions: scopes,
+	}
+
+	return r
+}
+
+// AskForPermissions is a convenience method for answering a request that needs permissions the user has not
+// granted yet. The speech is spoken, a consent card for the scopes is sent and the session is ended.
+func (r *EchoResponse) AskForPermissions(speech string, scopes ...string) *EchoResponse {
+	return r.OutputSpeech(speech).AskForPermissionsConsentCard(scopes...).EndSession(true)
+}
+
 // Reprompt will send a prompt back to the user, this could be used to request additional information from the user.
 func (r *EchoResponse) Reprompt(text string) *EchoResponse {
 	r.Response.Reprompt = &EchoReprompt{
@@ -215,24 +289,28 @@ func (r *EchoResponse) RepromptSSML(text string) *EchoResponse {
 
 // EndSession is a convenience method for setting the flag in the response that will
 // indicate if the session between the end user's device and the skillserver should be closed.
+// Data in the SessionStore of the application is removed once a response ends the session.
 func (r *EchoResponse) EndSession(flag bool) *EchoResponse {
 	r.Response.ShouldEndSession = flag
+	r.endSession = flag
 
 	return r
 }
 
 // RespondToIntent is used to Delegate/Elicit/Confirm a dialog or an entire intent with
-// user of alexa. The func takes in name of the dialog, updated intent/intent to confirm
-// if any and optional slot value. It prepares a Echo Response to be returned.
+// user of alexa. The func takes in name of the dialog, updated intent if any and optional
+// slot value. It prepares a Echo Response to be returned and keeps the session open, as the
+// Alexa service rejects dialog directives on a response that ends the session. A session ended
+// explicitly with `EndSession(true)` is left as it is and reported by `Validate`.
 // Multiple directives can be returned by calling the method in chain
 // (eg. RespondToIntent(...).RespondToIntent(...), each RespondToIntent call appends the
 // data to Directives array and will return the same at the end.
+// The prompts ElicitSlot, ConfirmSlot and ConfirmIntent need can be set with `OutputSpeech`
+// and `Reprompt`, or the DialogElicitSlot, DialogConfirmSlot and DialogConfirmIntent methods can be used instead.
 func (r *EchoResponse) RespondToIntent(name dialog.Type, intent *EchoIntent, slot *EchoSlot) *EchoResponse {
 	directive := EchoDirective{Type: name}
-	if intent != nil && name == dialog.ConfirmIntent {
-		directive.IntentToConfirm = intent.Name
-	} else {
-		directive.UpdatedIntent = intent
+	if intent != nil && name != dialog.UpdateDynamicEntities {
+		directive.UpdatedIntent = updatedIntent(intent)
 	}
 
 	if slot != nil {
@@ -242,10 +320,151 @@ func (r *EchoResponse) RespondToIntent(name dialog.Type, intent *EchoIntent, slo
 			directive.SlotToConfirm = slot.Name
 		}
 	}
+
+	if name != dialog.UpdateDynamicEntities && !r.endSession {
+		r.Response.ShouldEndSession = false
+	}
+
 	r.Response.Directives = append(r.Response.Directives, &directive)
 	return r
 }
 
+// updatedIntent copies an intent for use in a dialog directive, filling in the confirmation statuses
+// the Alexa service requires.
+func updatedIntent(intent *EchoIntent) *EchoIntent {
+	updated := *intent
+	if updated.ConfirmationStatus == "" {
+		updated.ConfirmationStatus = ConfNone
+	}
+
+	updated.Slots = make(map[string]EchoSlot, len(intent.Slots))
+	for name, slot := range intent.Slots {
+		if slot.Name == "" {
+			slot.Name = name
+		}
+
+		if slot.ConfirmationStatus == "" {
+			slot.ConfirmationStatus = ConfNone
+		}
+
+		updated.Slots[name] = slot
+	}
+
+	return &updated
+}
+
+// DialogDelegate hands the next turn of the dialog to the Alexa service, which will use the prompts
+// configured in the developer console. The updated intent is optional and can be used to change slot
+// values or to switch to a different intent. No speech may be set on a response that delegates.
+func (r *EchoResponse) DialogDelegate(updatedIntent *EchoIntent) *EchoResponse {
+	return r.RespondToIntent(dialog.Delegate, updatedIntent, nil)
+}
+
+// DialogElicitSlot asks the user for the value of the named slot with the provided prompt and reprompt.
+func (r *EchoResponse) DialogElicitSlot(slotName string, updatedIntent *EchoIntent, prompt, reprompt string) *EchoResponse {
+	return r.OutputSpeech(prompt).
+		Reprompt(reprompt).
+		RespondToIntent(dialog.ElicitSlot, updatedIntent, &EchoSlot{Name: slotName})
+}
+
+// DialogConfirmSlot asks the user to confirm the value of the named slot with the provided prompt and reprompt.
+func (r *EchoResponse) DialogConfirmSlot(slotName string, updatedIntent *EchoIntent, prompt, reprompt string) *EchoResponse {
+	return r.OutputSpeech(prompt).
+		Reprompt(reprompt).
+		RespondToIntent(dialog.ConfirmSlot, updatedIntent, &EchoSlot{Name: slotName})
+}
+
+// DialogConfirmIntent asks the user to confirm the whole intent with the provided prompt and reprompt.
+func (r *EchoResponse) DialogConfirmIntent(updatedIntent *EchoIntent, prompt, reprompt string) *EchoResponse {
+	return r.OutputSpeech(prompt).
+		Reprompt(reprompt).
+		RespondToIntent(dialog.ConfirmIntent, updatedIntent, nil)
+}
+
+// Validate checks the response for combinations of directives and speech that the Alexa service
+// rejects, such as a Dialog.Delegate with a reprompt or two dialog directives in one response.
+// Responses written by an EchoApplication are validated before they are sent.
+func (r *EchoResponse) Validate() error {
+	dialogDirectives := 0
+
+	if r.Response.launchesVideo() && r.Response.Reprompt != nil {
+		return errors.New("VideoApp.Launch can not be sent with a reprompt")
+	}
+
+	for _, d := range r.Response.Directives {
+		directive, ok := d.(*EchoDirective)
+		if !ok {
+			continue
+		}
+
+		switch directive.Type {
+		case dialog.UpdateDynamicEntities:
+			if directive.UpdateBehavior == dialog.Replace && len(directive.Types) == 0 {
+				return errors.New("Dialog.UpdateDynamicEntities with REPLACE requires at least one type")
+			}
+
+			if directive.UpdateBehavior == dialog.Clear && len(directive.Types) > 0 {
+				return errors.New("Dialog.UpdateDynamicEntities with CLEAR can not include types")
+			}
+
+			if directive.UpdateBehavior != dialog.Replace && directive.UpdateBehavior != dialog.Clear {
+				return errors.New("Dialog.UpdateDynamicEntities requires updateBehavior REPLACE or CLEAR")
+			}
+
+			continue
+		case dialog.Delegate:
+			if r.Response.Reprompt != nil {
+				return errors.New("Dialog.Delegate can not be sent with a reprompt")
+			}
+
+			if r.Response.OutputSpeech != nil && !directive.chained {
+				return errors.New("Dialog.Delegate can not be sent with outputSpeech unless it chains to another intent")
+			}
+		case dialog.ElicitSlot, dialog.ConfirmSlot, dialog.ConfirmIntent:
+			if r.Response.OutputSpeech == nil {
+				return errors.New(string(directive.Type) + " requires outputSpeech to prompt the user")
+			}
+
+			if directive.Type == dialog.ElicitSlot && directive.SlotToElicit == "" {
+				return errors.New("Dialog.ElicitSlot requires slotToElicit")
+			}
+
+			if directive.Type == dialog.ConfirmSlot && directive.SlotToConfirm == "" {
+				return errors.New("Dialog.ConfirmSlot requires slotToConfirm")
+			}
+		default:
+			continue
+		}
+
+		if r.Response.ShouldEndSession {
+			return errors.New(string(directive.Type) + " can not be sent on a response that ends the session")
+		}
+
+		dialogDirectives++
+		if dialogDirectives > 1 {
+			return errors.New("only one dialog directive can be sent in a response")
+		}
+	}
+
+	return nil
+}
+
+// RemoveUnsupportedDirectives drops every InterfaceDirective requiring an interface the device of the
+// request does not support, so a single response can be written for devices with and without screens.
+func (r *EchoResponse) RemoveUnsupportedDirectives(req *EchoRequest) *EchoResponse {
+	supported := r.Response.Directives[:0]
+	for _, directive := range r.Response.Directives {
+		if d, ok := directive.(InterfaceDirective); ok && !req.SupportsInterface(d.RequiredInterface()) {
+			continue
+		}
+
+		supported = append(supported, directive)
+	}
+
+	r.Response.Directives = supported
+	return r
+}
+
 func (r *EchoResponse) String() ([]byte, error) {
 	jsonStr, err := json.Marshal(r)
 	if err != nil {
@@ -264,6 +483,9 @@ type EchoRequest struct {
 	Session EchoSession `json:"session"`
 	Request EchoReqBody `json:"request"`
 	Context EchoContext `json:"context"`
+
+	persistence *persistenceManager
+	sessionData *sessionDataManager
 }
 
 // EchoSession contains information about the ongoing session between the Alexa server and
@@ -286,23 +508,57 @@ type EchoSession struct {
 type EchoContext struct {
 	System struct {
 		Device struct {
-			DeviceID string `json:"deviceId,omitempty"`
+			DeviceID            string                 `json:"deviceId,omitempty"`
+			SupportedInterfaces map[string]interface{} `json:"supportedInterfaces,omitempty"`
 		} `json:"device,omitempty"`
 		Application struct {
 			ApplicationID string `json:"applicationId,omitempty"`
 		} `json:"application,omitempty"`
+		User struct {
+			UserID      string `json:"userId,omitempty"`
+			AccessToken string `json:"accessToken,omitempty"`
+		} `json:"user,omitempty"`
+		Person struct {
+			PersonID    string `json:"personId,omitempty"`
+			AccessToken string `json:"accessToken,omitempty"`
+		} `json:"person,omitempty"`
+		APIEndpoint    string `json:"apiEndpoint,omitempty"`
+		APIAccessToken string `json:"apiAccessToken,omitempty"`
 	} `json:"System,omitempty"`
 }
 
 // EchoReqBody contains all data related to the type of request sent.
 type EchoReqBody struct {
-	Type        string     `json:"type"`
-	RequestID   string     `json:"requestId"`
-	Timestamp   string     `json:"timestamp"`
-	Intent      EchoIntent `json:"intent,omitempty"`
-	Reason      string     `json:"reason,omitempty"`
-	Locale      string     `json:"locale,omitempty"`
-	DialogState string     `json:"dialogState,omitempty"`
+	Type        string                 `json:"type"`
+	RequestID   string                 `json:"requestId"`
+	Timestamp   string                 `json:"timestamp"`
+	Intent      EchoIntent             `json:"intent,omitempty"`
+	Reason      string                 `json:"reason,omitempty"`
+	Error       *EchoReqError          `json:"error,omitempty"`
+	APIRequest  *EchoAPIRequest        `json:"apiRequest,omitempty"`
+	Token       string                 `json:"token,omitempty"`
+	Arguments   []interface{}          `json:"arguments,omitempty"`
+	Source      map[string]interface{} `json:"source,omitempty"`
+	Components  map[string]interface{} `json:"components,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Status      *EchoConnectionsStatus `json:"status,omitempty"`
+	Payload     json.RawMessage        `json:"payload,omitempty"`
+	Task        *EchoTask              `json:"task,omitempty"`
+	Body        json.RawMessage        `json:"body,omitempty"`
+	Message     json.RawMessage        `json:"message,omitempty"`
+	Locale      string                 `json:"locale,omitempty"`
+	DialogState string                 `json:"dialogState,omitempty"`
+
+	// EventCreationTime and EventPublishingTime are set on events sent outside of a session, such as
+	// household list and skill events, whose details are in Body.
+	EventCreationTime   string `json:"eventCreationTime,omitempty"`
+	EventPublishingTime string `json:"eventPublishingTime,omitempty"`
+}
+
+// EchoReqError contains the details of an error that caused the Alexa service to end a session.
+type EchoReqError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
 }
 
 // EchoIntent represents the intent that is sent as part of an EchoRequest. This includes
@@ -328,7 +584,7 @@ type EchoSlot struct {
 // the authority will be the custom slot type that was defined.
 // Find more information here: https://developer.amazon.com/docs/custom-skills/define-synonyms-and-ids-for-slot-type-values-entity-resolution.html#intentrequest-changes
 type EchoResolution struct {
-	ResolutionsPerAuthority []EchoResolutionPerAuthority `json:"resolutionsPerAuthority"`
+	ResolutionsPerAuthority []EchoResolutionPerAuthority `json:"resolutionsPerAuthority,omitempty"`
 }
 
 // EchoResolutionPerAuthority contains information about a single slot resolution from a single
@@ -353,6 +609,8 @@ type EchoResponse struct {
 	Version           string                 `json:"version"`
 	SessionAttributes map[string]interface{} `json:"sessionAttributes,omitempty"`
 	Response          EchoRespBody           `json:"response"`
+
+	endSession bool
 }
 
 // EchoRespBody contains the body of the response to be sent back to the Alexa service.
@@ -363,7 +621,22 @@ type EchoRespBody struct {
 	Card             *EchoRespPayload `json:"card,omitempty"`
 	Reprompt         *EchoReprompt    `json:"reprompt,omitempty"` // Pointer so it's dropped if empty in JSON response.
 	ShouldEndSession bool             `json:"shouldEndSession"`
-	Directives       []*EchoDirective `json:"directives,omitempty"`
+	Directives       []Directive      `json:"directives,omitempty"`
+	APIResponse      interface{}      `json:"apiResponse,omitempty"`
+}
+
+// MarshalJSON leaves out shouldEndSession when the body launches a video, as the Alexa service rejects
+// VideoApp.Launch responses that include it.
+func (b EchoRespBody) MarshalJSON() ([]byte, error) {
+	type body EchoRespBody
+	if !b.launchesVideo() {
+		return json.Marshal(body(b))
+	}
+
+	return json.Marshal(struct {
+		body
+		ShouldEndSession *bool `json:"shouldEndSession,omitempty"`
+	}{body: body(b)})
 }
 
 // EchoReprompt contains speech that should be spoken back to the end user to retrieve
@@ -388,15 +661,37 @@ type EchoRespPayload struct {
 	SSML    string        `json:"ssml,omitempty"`
 	Content string        `json:"content,omitempty"`
 	Image   EchoRespImage `json:"image,omitempty"`
+
+	// Permissions lists the scopes requested by an AskForPermissionsConsent card.
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 // EchoDirective includes information about intents and slots that should be confirmed or elicted from the user.
 // The type value can be used to delegate the action to the Alexa service. In this case, a pre-configured prompt
-// will be used from the developer console.
+// will be used from the developer console. Dialog.UpdateDynamicEntities directives carry the update behavior and slot
+// types instead, and Dialog.DelegateRequest directives the target and the request handed to it.
 type EchoDirective struct {
-	Type            dialog.Type `json:"type"`
-	UpdatedIntent   *EchoIntent `json:"updatedIntent,omitempty"`
-	SlotToConfirm   string      `json:"slotToConfirm,omitempty"`
-	SlotToElicit    string      `json:"slotToElicit,omitempty"`
-	IntentToConfirm string      `json:"intentToConfirm,omitempty"`
+	Type          dialog.Type `json:"type"`
+	UpdatedIntent *EchoIntent `json:"updatedIntent,omitempty"`
+	SlotToConfirm string      `json:"slotToConfirm,omitempty"`
+	SlotToElicit  string      `json:"slotToElicit,omitempty"`
+
+	// Deprecated: Dialog.ConfirmIntent directives carry the intent in UpdatedIntent, which RespondToIntent
+	// sets. IntentToConfirm is no longer set and is only kept for existing code.
+	IntentToConfirm string `json:"intentToConfirm,omitempty"`
+
+	UpdateBehavior dialog.UpdateBehavior `json:"updateBehavior,omitempty"`
+	Types          []EchoEntityType      `json:"types,omitempty"`
+
+	Target         string                `json:"target,omitempty"`
+	Period         *EchoDelegatePeriod   `json:"period,omitempty"`
+	UpdatedRequest *EchoDelegatedRequest `json:"updatedRequest,omitempty"`
+
+	// chained marks a Dialog.Delegate written by ChainToIntent, which may be sent with output speech.
+	chained bool
+}
+
+// DirectiveType implements Directive.
+func (d *EchoDirective) DirectiveType() string {
+	return string(d.Type)
 }
@@ -0,0 +1,103 @@
+package skillserver
+
+import (
+	"strings"
+
+	"github.com/mikeflynn/go-alexa/skillserver/dialog"
+)
+
+// dynamicAuthorityPrefix starts the authority of entity resolutions matched against dynamic entities.
+// Resolutions against the slot types of the interaction model use "amzn1.er-authority.echo-sdk." directly.
+const dynamicAuthorityPrefix = "amzn1.er-authority.echo-sdk.dynamic."
+
+// ResolutionMatch is the status code of an entity resolution authority that matched the slot value.
+const ResolutionMatch = "ER_SUCCESS_MATCH"
+
+// EchoEntityType holds the dynamic values of a single custom slot type. The name must match a slot type
+// of the interaction model.
+type EchoEntityType struct {
+	Name   string            `json:"name"`
+	Values []EchoEntityValue `json:"values"`
+}
+
+// EchoEntityValue is a single dynamic slot type value with its optional ID and synonyms.
+type EchoEntityValue struct {
+	ID   string `json:"id,omitempty"`
+	Name struct {
+		Value    string   `json:"value"`
+		Synonyms []string `json:"synonyms,omitempty"`
+	} `json:"name"`
+}
+
+// NewEntityType returns an empty set of dynamic values for the named slot type.
+func NewEntityType(name string) *EchoEntityType {
+	return &EchoEntityType{Name: name, Values: []EchoEntityValue{}}
+}
+
+// AddValue adds a value with an ID and any synonyms to the slot type.
+func (t *EchoEntityType) AddValue(id, value string, synonyms ...string) *EchoEntityType {
+	entity := EchoEntityValue{ID: id}
+	entity.Name.Value = value
+	entity.Name.Synonyms = synonyms
+
+	t.Values = append(t.Values, entity)
+
+	return t
+}
+
+// ReplaceDynamicEntities adds a Dialog.UpdateDynamicEntities directive that replaces all dynamic entities
+// of the current user with the provided slot types. Dynamic entities expire after 30 minutes.
+func (r *EchoResponse) ReplaceDynamicEntities(types ...*EchoEntityType) *EchoResponse {
+	directive := EchoDirective{Type: dialog.UpdateDynamicEntities, UpdateBehavior: dialog.Replace}
+	for _, t := range types {
+		directive.Types = append(directive.Types, *t)
+	}
+
+	r.Response.Directives = append(r.Response.Directives, &directive)
+
+	return r
+}
+
+// ClearDynamicEntities adds a Dialog.UpdateDynamicEntities directive that removes all dynamic entities
+// of the current user.
+func (r *EchoResponse) ClearDynamicEntities() *EchoResponse {
+	r.Response.Directives = append(r.Response.Directives, &EchoDirective{
+		Type:           dialog.UpdateDynamicEntities,
+		UpdateBehavior: dialog.Clear,
+	})
+
+	return r
+}
+
+// IsDynamic reports whether the resolution came from the dynamic entities of the user rather than the
+// slot type values of the interaction model.
+func (a EchoResolutionPerAuthority) IsDynamic() bool {
+	return strings.HasPrefix(a.Authority, dynamicAuthorityPrefix)
+}
+
+// Matched reports whether the authority resolved the slot value to at least one of its values.
+func (a EchoResolutionPerAuthority) Matched() bool {
+	return a.Status.Code == ResolutionMatch
+}
+
+// StaticResolutions returns the resolutions of the slot that matched values of the interaction model.
+func (s EchoSlot) StaticResolutions() []EchoResolutionPerAuthority {
+	return s.matchedResolutions(false)
+}
+
+// DynamicResolutions returns the resolutions of the slot that matched dynamic entities of the user.
+func (s EchoSlot) DynamicResolutions() []EchoResolutionPerAuthority {
+	return s.matchedResolutions(true)
+}
+
+func (s EchoSlot) matchedResolutions(dynamic bool) []EchoResolutionPerAuthority {
+	var matched []EchoResolutionPerAuthority
+
+	for _, authority := range s.Resolutions.ResolutionsPerAuthority {
+		if authority.Matched() && authority.IsDynamic() == dynamic {
+			matched = append(matched, authority)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,30 @@
+package skillserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+)
+
+// ServeEcho runs the handlers of the application for the request as the Echo endpoint does, without
+// verifying the request. It is only available to the tests of this package, which use the alexatest
+// stand-in from outside of it.
+func ServeEcho(app EchoApplication, req *EchoRequest) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/echo/test", nil)
+	r = r.WithContext(context.WithValue(r.Context(), requestContextKey("echoRequest"), req))
+
+	w := httptest.NewRecorder()
+	app.serveEcho(w, r)
+
+	return w
+}
+
+// Router returns the router Run serves the applications with, including the checks of the requests.
+func Router(apps map[string]interface{}) http.Handler {
+	router := mux.NewRouter()
+	initialize(apps, router)
+
+	return router
+}
@@ -0,0 +1,275 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Details about the List Management API and list events can be found on this page:
+// https://developer.amazon.com/docs/custom-skills/access-the-alexa-shopping-and-to-do-lists.html
+
+const (
+	// ListReadScope is the permission needed to read the lists of the user.
+	ListReadScope = "read::alexa:household:list"
+
+	// ListWriteScope is the permission needed to change the lists of the user.
+	ListWriteScope = "write::alexa:household:list"
+)
+
+const (
+	// ShoppingListName is the name of the shopping list every user has.
+	ShoppingListName = "Alexa shopping list"
+
+	// ToDoListName is the name of the to-do list every user has.
+	ToDoListName = "Alexa to-do list"
+)
+
+const (
+	// ListCreatedEvent is sent when the user creates a custom list.
+	ListCreatedEvent = "AlexaHouseholdListEvent.ListCreated"
+	// ListUpdatedEvent is sent when the user renames or archives a list.
+	ListUpdatedEvent = "AlexaHouseholdListEvent.ListUpdated"
+	// ListDeletedEvent is sent when the user deletes a custom list.
+	ListDeletedEvent = "AlexaHouseholdListEvent.ListDeleted"
+	// ListItemsCreatedEvent is sent when the user adds items to a list.
+	ListItemsCreatedEvent = "AlexaHouseholdListEvent.ItemsCreated"
+	// ListItemsUpdatedEvent is sent when the user changes or completes items of a list.
+	ListItemsUpdatedEvent = "AlexaHouseholdListEvent.ItemsUpdated"
+	// ListItemsDeletedEvent is sent when the user removes items from a list.
+	ListItemsDeletedEvent = "AlexaHouseholdListEvent.ItemsDeleted"
+)
+
+const householdListsPath = "/v2/householdlists/"
+
+// ErrListNotFound is returned when the list or list item does not exist.
+var ErrListNotFound = errors.New("list or list item not found")
+
+// ListState is the state of a household list.
+type ListState string
+
+const (
+	// ListActive lists are shown to the user.
+	ListActive ListState = "active"
+
+	// ListArchived lists are kept but hidden from the user.
+	ListArchived ListState = "archived"
+)
+
+// ListItemStatus is the status of an item of a household list.
+type ListItemStatus string
+
+const (
+	// ItemActive items are still to be bought or done.
+	ItemActive ListItemStatus = "active"
+
+	// ItemCompleted items have been checked off.
+	ItemCompleted ListItemStatus = "completed"
+)
+
+// HouseholdList is a list of the user. Items are only filled in by `ListsClient.GetList`, and StatusMap only
+// by `ListsClient.Lists`.
+type HouseholdList struct {
+	ListID    string           `json:"listId"`
+	Name      string           `json:"name"`
+	State     ListState        `json:"state"`
+	Version   int              `json:"version"`
+	StatusMap []ListStatusLink `json:"statusMap,omitempty"`
+	Items     []ListItem       `json:"items,omitempty"`
+}
+
+// ListStatusLink links to the items of a list with a status.
+type ListStatusLink struct {
+	Href   string         `json:"href"`
+	Status ListItemStatus `json:"status"`
+}
+
+// ListItem is a single item of a household list. Its version must be sent back when it is updated.
+type ListItem struct {
+	ID          string         `json:"id"`
+	Version     int            `json:"version"`
+	Value       string         `json:"value"`
+	Status      ListItemStatus `json:"status"`
+	CreatedTime string         `json:"createdTime,omitempty"`
+	UpdatedTime string         `json:"updatedTime,omitempty"`
+	Href        string         `json:"href,omitempty"`
+}
+
+// EchoListEventBody is the body of an AlexaHouseholdListEvent request. ListItemIDs is only set for item events.
+type EchoListEventBody struct {
+	ListID      string   `json:"listId"`
+	ListItemIDs []string `json:"listItemIds,omitempty"`
+}
+
+// GetListEvent is a convenience method for getting the list and items an AlexaHouseholdListEvent request is about.
+func (r *EchoRequest) GetListEvent() (*EchoListEventBody, error) {
+	if !strings.HasPrefix(r.GetRequestType(), "AlexaHouseholdListEvent.") || len(r.Request.Body) == 0 {
+		return nil, errors.New("request is not an AlexaHouseholdListEvent")
+	}
+
+	var body EchoListEventBody
+	if err := json.Unmarshal(r.Request.Body, &body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// ListsClient reads and changes the household lists of the user. Methods return ErrPermissionMissing if the
+// user has not granted ListReadScope or ListWriteScope, and ErrListNotFound for unknown lists and items.
+type ListsClient struct {
+	api *APIClient
+}
+
+// NewListsClient returns a ListsClient using the API client.
+func NewListsClient(api *APIClient) *ListsClient {
+	return &ListsClient{api: api}
+}
+
+// Lists returns a ListsClient for the user of the request. It can also be used for AlexaHouseholdListEvent
+// requests, which are sent outside of a session.
+func (r *EchoRequest) Lists() *ListsClient {
+	return NewListsClient(r.APIClient())
+}
+
+// Lists returns all lists of the user without their items.
+func (c *ListsClient) Lists() ([]HouseholdList, error) {
+	var lists struct {
+		Lists []HouseholdList `json:"lists"`
+	}
+
+	if err := c.api.Do("GET", householdListsPath, nil, nil, &lists); err != nil {
+		return nil, listError(err)
+	}
+
+	return lists.Lists, nil
+}
+
+// FindList returns the list with the name, or ErrListNotFound if the user has no such list.
+func (c *ListsClient) FindList(name string) (*HouseholdList, error) {
+	lists, err := c.Lists()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, list := range lists {
+		if list.Name == name {
+			return &list, nil
+		}
+	}
+
+	return nil, ErrListNotFound
+}
+
+// ShoppingList returns the shopping list of the user without its items.
+func (c *ListsClient) ShoppingList() (*HouseholdList, error) {
+	return c.FindList(ShoppingListName)
+}
+
+// ToDoList returns the to-do list of the user without its items.
+func (c *ListsClient) ToDoList() (*HouseholdList, error) {
+	return c.FindList(ToDoListName)
+}
+
+// GetList returns the list with its items of the status.
+func (c *ListsClient) GetList(listID string, status ListItemStatus) (*HouseholdList, error) {
+	list := &HouseholdList{}
+	if err := c.api.Do("GET", listPath(listID)+"/"+string(status), nil, nil, list); err != nil {
+		return nil, listError(err)
+	}
+
+	return list, nil
+}
+
+// CreateList creates a custom list with the name.
+func (c *ListsClient) CreateList(name string) (*HouseholdList, error) {
+	body := map[string]interface{}{"name": name, "state": ListActive}
+
+	list := &HouseholdList{}
+	if err := c.api.Do("POST", householdListsPath, nil, body, list); err != nil {
+		return nil, listError(err)
+	}
+
+	return list, nil
+}
+
+// UpdateList renames a list or changes its state. The version must be the current version of the list.
+func (c *ListsClient) UpdateList(listID, name string, state ListState, version int) (*HouseholdList, error) {
+	body := map[string]interface{}{"name": name, "state": state, "version": version}
+
+	list := &HouseholdList{}
+	if err := c.api.Do("PUT", listPath(listID), nil, body, list); err != nil {
+		return nil, listError(err)
+	}
+
+	return list, nil
+}
+
+// DeleteList deletes a custom list. The shopping and to-do lists can't be deleted.
+func (c *ListsClient) DeleteList(listID string) error {
+	return listError(c.api.Do("DELETE", listPath(listID), nil, nil, nil))
+}
+
+// GetItem returns a single item of a list.
+func (c *ListsClient) GetItem(listID, itemID string) (*ListItem, error) {
+	item := &ListItem{}
+	if err := c.api.Do("GET", itemPath(listID, itemID), nil, nil, item); err != nil {
+		return nil, listError(err)
+	}
+
+	return item, nil
+}
+
+// CreateItem adds an item with the value and status to a list.
+func (c *ListsClient) CreateItem(listID, value string, status ListItemStatus) (*ListItem, error) {
+	body := map[string]interface{}{"value": value, "status": status}
+
+	item := &ListItem{}
+	if err := c.api.Do("POST", listPath(listID)+"/items", nil, body, item); err != nil {
+		return nil, listError(err)
+	}
+
+	return item, nil
+}
+
+// UpdateItem changes the value and status of an item. The version must be the current version of the item.
+func (c *ListsClient) UpdateItem(listID, itemID, value string, status ListItemStatus, version int) (*ListItem, error) {
+	body := map[string]interface{}{"value": value, "status": status, "version": version}
+
+	item := &ListItem{}
+	if err := c.api.Do("PUT", itemPath(listID, itemID), nil, body, item); err != nil {
+		return nil, listError(err)
+	}
+
+	return item, nil
+}
+
+// SetItemStatus changes the status of the item, e.g. to check it off with ItemCompleted, keeping its value.
+func (c *ListsClient) SetItemStatus(listID string, item ListItem, status ListItemStatus) (*ListItem, error) {
+	return c.UpdateItem(listID, item.ID, item.Value, status, item.Version)
+}
+
+// DeleteItem removes an item from a list.
+func (c *ListsClient) DeleteItem(listID, itemID string) error {
+	return listError(c.api.Do("DELETE", itemPath(listID, itemID), nil, nil, nil))
+}
+
+func listPath(listID string) string {
+	return householdListsPath + url.PathEscape(listID)
+}
+
+func itemPath(listID, itemID string) string {
+	return listPath(listID) + "/items/" + url.PathEscape(itemID)
+}
+
+// listError maps the errors of the List Management API to ErrListNotFound and ErrPermissionMissing. Other
+// errors, such as a 409 for an outdated version, are returned as they are.
+func listError(err error) error {
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+		return ErrListNotFound
+	}
+
+	return permissionError(err)
+}
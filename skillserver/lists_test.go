@@ -0,0 +1,151 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestListItemLifecycle(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.EchoRequest().Lists()
+
+	shopping, err := client.ShoppingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := client.CreateItem(shopping.ListID, "milk", alexa.ItemActive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.ID == "" || item.Version != 1 {
+		t.Fatalf("created item = %+v", item)
+	}
+
+	done, err := client.SetItemStatus(shopping.ListID, *item, alexa.ItemCompleted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if done.Status != alexa.ItemCompleted || done.Version != 2 {
+		t.Fatalf("completed item = %+v", done)
+	}
+
+	if _, err := client.SetItemStatus(shopping.ListID, *item, alexa.ItemActive); err == nil {
+		t.Fatal("SetItemStatus accepted a stale version")
+	}
+
+	completed, err := client.GetList(shopping.ListID, alexa.ItemCompleted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(completed.Items) != 1 || completed.Items[0].Value != "milk" {
+		t.Fatalf("completed items = %+v", completed.Items)
+	}
+
+	if err := client.DeleteItem(shopping.ListID, item.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if items := server.ListItems(shopping.ListID, ""); len(items) != 0 {
+		t.Fatalf("items after DeleteItem = %+v", items)
+	}
+}
+
+func TestCustomListLifecycle(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.EchoRequest().Lists()
+
+	packing, err := client.CreateList("Packing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found, err := client.FindList("Packing"); err != nil || found.ListID != packing.ListID {
+		t.Fatalf("FindList = %+v, %v", found, err)
+	}
+
+	archived, err := client.UpdateList(packing.ListID, "Packing list", alexa.ListArchived, packing.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if archived.Name != "Packing list" || archived.State != alexa.ListArchived {
+		t.Fatalf("updated list = %+v", archived)
+	}
+
+	if err := client.DeleteList(packing.ListID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetList(packing.ListID, alexa.ItemActive); err != alexa.ErrListNotFound {
+		t.Fatalf("GetList after DeleteList returned %v, want ErrListNotFound", err)
+	}
+}
+
+func TestListEvent(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	shopping, err := server.EchoRequest().Lists().ShoppingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added := server.AddListItem(shopping.ListID, "eggs")
+	req := server.ListEvent(alexa.ListItemsCreatedEvent, shopping.ListID, added.ID)
+
+	var event *alexa.EchoListEventBody
+	app := alexa.EchoApplication{
+		OnListEvent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			event, err = req.GetListEvent()
+		},
+	}
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.ListID != shopping.ListID || len(event.ListItemIDs) != 1 || event.ListItemIDs[0] != added.ID {
+		t.Fatalf("list event = %+v", event)
+	}
+
+	item, err := req.Lists().GetItem(event.ListID, event.ListItemIDs[0])
+	if err != nil || item.Value != "eggs" {
+		t.Fatalf("GetItem = %+v, %v", item, err)
+	}
+}
+
+func TestListPermissionMissing(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.EchoRequest().Lists()
+
+	shopping, err := client.ShoppingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.SetPermission(alexa.ListWriteScope, false)
+	if _, err := client.CreateItem(shopping.ListID, "bread", alexa.ItemActive); err != alexa.ErrPermissionMissing {
+		t.Fatalf("CreateItem without permission returned %v, want ErrPermissionMissing", err)
+	}
+
+	server.SetPermission(alexa.ListReadScope, false)
+	if _, err := client.Lists(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("Lists without permission returned %v, want ErrPermissionMissing", err)
+	}
+}
@@ -0,0 +1,172 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Details about requesting access tokens with the client credentials of a skill can be found on this page:
+// https://developer.amazon.com/docs/login-with-amazon/authorization-code-grant.html
+
+const (
+	// DefaultLWATokenEndpoint is the Login with Amazon endpoint issuing access tokens for skills.
+	DefaultLWATokenEndpoint = "https://api.amazon.com/auth/o2/token"
+
+	// DefaultTokenRefreshMargin is how long before it expires a cached access token is replaced.
+	DefaultTokenRefreshMargin = time.Minute
+)
+
+// TokenSource provides the access token an APIClient sends. Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// LWAError is returned by an LWATokenSource when Login with Amazon refuses to issue a token, e.g. with
+// the code "invalid_client" for a wrong client secret.
+type LWAError struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *LWAError) Error() string {
+	msg := fmt.Sprintf("login with amazon returned status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+
+	return msg
+}
+
+// LWATokenSource requests access tokens from Login with Amazon with the client ID and secret of the skill,
+// found on the Permissions page of the developer console. Tokens are cached and shared by all goroutines
+// until RefreshBefore their expiry.
+type LWATokenSource struct {
+	ClientID     string
+	ClientSecret string
+
+	// Scopes are the scopes requested for the token, such as `ProactiveEventsScope`.
+	Scopes []string
+
+	// Endpoint is the endpoint tokens are requested from. `DefaultLWATokenEndpoint` is used when empty.
+	Endpoint string
+
+	// RefreshBefore is how long before it expires a token is replaced. `DefaultTokenRefreshMargin` is used
+	// when it is 0. It is capped at half the lifetime of the token, so that short-lived tokens are still reused.
+	RefreshBefore time.Duration
+
+	// HTTPClient is used to make the requests. A client with a 10 second timeout is used when nil.
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	margin  time.Duration
+}
+
+// NewLWATokenSource returns a token source requesting tokens with the scopes for the client credentials.
+func NewLWATokenSource(clientID, clientSecret string, scopes ...string) *LWATokenSource {
+	return &LWATokenSource{ClientID: clientID, ClientSecret: clientSecret, Scopes: scopes}
+}
+
+// Token returns the cached access token, requesting a new one if there is none or it is about to expire.
+// Concurrent callers wait for a single request instead of each requesting a token.
+func (s *LWATokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(s.margin).Before(s.expires) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.requestToken()
+	if err != nil {
+		return "", err
+	}
+
+	margin := s.RefreshBefore
+	if margin == 0 {
+		margin = DefaultTokenRefreshMargin
+	}
+
+	if margin > expiresIn/2 {
+		margin = expiresIn / 2
+	}
+
+	s.token = token
+	s.expires = time.Now().Add(expiresIn)
+	s.margin = margin
+
+	return s.token, nil
+}
+
+// Invalidate drops the cached token, so that the next call to Token requests a new one. APIClient calls it
+// when the API rejects the token.
+func (s *LWATokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = ""
+}
+
+func (s *LWATokenSource) requestToken() (string, time.Duration, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultLWATokenEndpoint
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"scope":         {strings.Join(s.Scopes, " ")},
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultAPIHTTPClient
+	}
+
+	resp, err := httpClient.Post(endpoint, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		lwaErr := &LWAError{StatusCode: resp.StatusCode}
+		json.Unmarshal(contents, lwaErr)
+
+		return "", 0, lwaErr
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	if err := json.Unmarshal(contents, &token); err != nil {
+		return "", 0, err
+	}
+
+	if token.AccessToken == "" {
+		return "", 0, &LWAError{StatusCode: resp.StatusCode, Description: "no access token in response"}
+	}
+
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
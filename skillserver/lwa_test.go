@@ -0,0 +1,27 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestLWATokenSourceShortLivedToken(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	// The token lives shorter than DefaultTokenRefreshMargin, which must not make every call request a new one.
+	server.SetTokenLifetime(30 * time.Second)
+	source := server.TokenSource("alexa::proactive_events")
+
+	for i := 0; i < 3; i++ {
+		if _, err := source.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := server.TokenRequests(); n != 1 {
+		t.Fatalf("%d token requests for a 30 second token, want 1", n)
+	}
+}
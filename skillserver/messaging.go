@@ -0,0 +1,64 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Details about the Skill Messaging API can be found on this page:
+// https://developer.amazon.com/docs/smapi/skill-messaging-api-reference.html
+
+// SkillMessagingScope is the LWA scope needed to send skill messages.
+const SkillMessagingScope = "alexa:skill_messaging"
+
+// SkillMessage is the body of a message sent to the skill for a user. Data is passed to the skill as the
+// message of the Messaging.MessageReceived request.
+type SkillMessage struct {
+	Data                interface{} `json:"data"`
+	ExpiresAfterSeconds int         `json:"expiresAfterSeconds,omitempty"`
+}
+
+// MessagingClient sends messages to the skill on behalf of a user, waking it with a Messaging.MessageReceived
+// request that carries an access token for the Alexa APIs of the user.
+type MessagingClient struct {
+	// TokenSource provides access tokens with the `SkillMessagingScope` scope.
+	TokenSource TokenSource
+
+	// Endpoint is the Alexa API endpoint of the region of the users. `DefaultAPIEndpoint` is used when empty.
+	Endpoint string
+
+	// HTTPClient is used to make the requests. A client with a 10 second timeout is used when nil.
+	HTTPClient *http.Client
+}
+
+// NewMessagingClient returns a client authenticated with the client ID and secret of the skill.
+func NewMessagingClient(clientID, clientSecret string) *MessagingClient {
+	return &MessagingClient{TokenSource: NewLWATokenSource(clientID, clientSecret, SkillMessagingScope)}
+}
+
+// Send sends the data to the skill for the user. The message is dropped if it can't be delivered within
+// the TTL, which is between one minute and a day; a TTL of 0 uses the default of an hour.
+func (c *MessagingClient) Send(userID string, data interface{}, ttl time.Duration) error {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+
+	api := &APIClient{Endpoint: endpoint, TokenSource: c.TokenSource, HTTPClient: c.HTTPClient}
+	message := SkillMessage{Data: data, ExpiresAfterSeconds: int(ttl / time.Second)}
+
+	return api.Do("POST", "/v1/skillmessages/users/"+url.PathEscape(userID), nil, message, nil)
+}
+
+// GetMessage is a convenience method for decoding the message of a Messaging.MessageReceived request into v.
+// The Alexa API clients of the request, such as `EchoRequest.Reminders`, use the access token it carries.
+func (r *EchoRequest) GetMessage(v interface{}) error {
+	if r.GetRequestType() != "Messaging.MessageReceived" || len(r.Request.Message) == 0 {
+		return errors.New("request is not a Messaging.MessageReceived")
+	}
+
+	return json.Unmarshal(r.Request.Message, v)
+}
@@ -0,0 +1,77 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+type jobMessage struct {
+	Job string `json:"job"`
+}
+
+func TestMessagingRoundTrip(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	if err := server.MessagingClient().Send("amzn1.ask.account.test", jobMessage{Job: "refill"}, 10*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := server.Messages()
+	if len(sent) != 1 || sent[0].UserID != "amzn1.ask.account.test" || sent[0].ExpiresAfterSeconds != 600 {
+		t.Fatalf("sent messages = %+v", sent)
+	}
+
+	var received jobMessage
+	app := alexa.EchoApplication{
+		OnMessageReceived: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			if err := req.GetMessage(&received); err != nil {
+				t.Fatal(err)
+			}
+
+			// The request carries a token for the Alexa APIs of the user.
+			reminder := alexa.NewReminder(alexa.RelativeTrigger(time.Hour)).AddContent("en-US", "Refill the coffee")
+			if _, err := req.Reminders().Create(reminder); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+
+	if w := alexa.ServeEcho(app, server.MessageReceived(sent[0])); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if received.Job != "refill" {
+		t.Fatalf("received message = %+v", received)
+	}
+
+	if reminders := server.Reminders(); len(reminders) != 1 {
+		t.Fatalf("%d reminders created from the message, want 1", len(reminders))
+	}
+}
+
+func TestMessagingRejectsShortTTL(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	if err := server.MessagingClient().Send("amzn1.ask.account.test", jobMessage{Job: "refill"}, time.Second); err == nil {
+		t.Fatal("Send accepted a TTL shorter than a minute")
+	}
+
+	if sent := server.Messages(); len(sent) != 0 {
+		t.Fatalf("%d messages sent with an invalid TTL", len(sent))
+	}
+}
+
+func TestGetMessageRequiresMessageReceived(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	var message jobMessage
+	if err := server.EchoRequest().GetMessage(&message); err == nil {
+		t.Fatal("GetMessage decoded a request that is not a Messaging.MessageReceived")
+	}
+}
@@ -0,0 +1,162 @@
+package skillserver
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Details about the Monetization Service API can be found on this page:
+// https://developer.amazon.com/docs/in-skill-purchase/in-skill-product-service.html
+
+// ProductType is the type of an in-skill product.
+type ProductType string
+
+const (
+	// ProductSubscription is a product giving access to content for a period of time.
+	ProductSubscription ProductType = "SUBSCRIPTION"
+
+	// ProductEntitlement is a product bought once and owned forever.
+	ProductEntitlement ProductType = "ENTITLEMENT"
+
+	// ProductConsumable is a product that can be bought and used up again and again.
+	ProductConsumable ProductType = "CONSUMABLE"
+)
+
+const (
+	// Entitled means the user owns the product.
+	Entitled = "ENTITLED"
+
+	// NotEntitled means the user does not own the product.
+	NotEntitled = "NOT_ENTITLED"
+
+	// Purchasable means the product can be offered to the user.
+	Purchasable = "PURCHASABLE"
+
+	// NotPurchasable means the product can't be offered to the user right now.
+	NotPurchasable = "NOT_PURCHASABLE"
+)
+
+const inSkillProductsPath = "/v1/users/~current/skills/~current/inSkillProducts"
+
+// InSkillProduct describes an in-skill product and the current user's entitlement to it.
+type InSkillProduct struct {
+	ProductID              string      `json:"productId"`
+	ReferenceName          string      `json:"referenceName"`
+	Type                   ProductType `json:"type"`
+	Name                   string      `json:"name"`
+	Summary                string      `json:"summary"`
+	Entitled               string      `json:"entitled"`
+	EntitlementReason      string      `json:"entitlementReason,omitempty"`
+	Purchasable            string      `json:"purchasable"`
+	ActiveEntitlementCount int         `json:"activeEntitlementCount"`
+	PurchaseMode           string      `json:"purchaseMode,omitempty"`
+}
+
+// InSkillProductsPage is a single page of in-skill products. More pages are available while IsTruncated is true.
+type InSkillProductsPage struct {
+	InSkillProducts []InSkillProduct `json:"inSkillProducts"`
+	IsTruncated     bool             `json:"isTruncated"`
+	NextToken       string           `json:"nextToken,omitempty"`
+}
+
+// ProductFilter narrows the in-skill products listed by a MonetizationClient. Empty fields are not filtered on.
+type ProductFilter struct {
+	ProductType ProductType
+	Purchasable string
+	Entitled    string
+}
+
+// MonetizationClient lists the in-skill products of the skill and the user's entitlements to them.
+type MonetizationClient struct {
+	api *APIClient
+}
+
+// NewMonetizationClient returns a MonetizationClient using the API client.
+func NewMonetizationClient(api *APIClient) *MonetizationClient {
+	return &MonetizationClient{api: api}
+}
+
+// Monetization returns a MonetizationClient for the user and locale of the request.
+func (r *EchoRequest) Monetization() *MonetizationClient {
+	return NewMonetizationClient(r.APIClient())
+}
+
+// ListProductsPage returns a single page of up to maxResults products, starting at the next token of the
+// previous page. A maxResults of 0 uses the default page size of the API.
+func (c *MonetizationClient) ListProductsPage(filter ProductFilter, nextToken string, maxResults int) (*InSkillProductsPage, error) {
+	query := url.Values{}
+	if filter.ProductType != "" {
+		query.Set("productType", string(filter.ProductType))
+	}
+
+	if filter.Purchasable != "" {
+		query.Set("purchasable", filter.Purchasable)
+	}
+
+	if filter.Entitled != "" {
+		query.Set("entitled", filter.Entitled)
+	}
+
+	if nextToken != "" {
+		query.Set("nextToken", nextToken)
+	}
+
+	if maxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(maxResults))
+	}
+
+	page := &InSkillProductsPage{}
+	if err := c.api.Do("GET", inSkillProductsPath, query, nil, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ListProducts returns every product matching the filter, following the pagination of the API.
+func (c *MonetizationClient) ListProducts(filter ProductFilter) ([]InSkillProduct, error) {
+	var products []InSkillProduct
+
+	nextToken := ""
+	for {
+		page, err := c.ListProductsPage(filter, nextToken, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, page.InSkillProducts...)
+
+		if !page.IsTruncated || page.NextToken == "" {
+			return products, nil
+		}
+
+		nextToken = page.NextToken
+	}
+}
+
+// GetProduct returns a single product by its ID.
+func (c *MonetizationClient) GetProduct(productID string) (*InSkillProduct, error) {
+	product := &InSkillProduct{}
+	if err := c.api.Do("GET", inSkillProductsPath+"/"+url.PathEscape(productID), nil, nil, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// IsEntitled reports whether the user owns the product. For a product ID the skill does not sell it returns
+// false together with the *APIError for the 404 of the API.
+func (c *MonetizationClient) IsEntitled(productID string) (bool, error) {
+	product, err := c.GetProduct(productID)
+	if err != nil {
+		return false, err
+	}
+
+	return product.Entitled == Entitled, nil
+}
+
+// IsEntitled is a convenience method for checking whether the user of the request owns the in-skill product.
+// Unknown products are reported the same way as by `MonetizationClient.IsEntitled`.
+func (r *EchoRequest) IsEntitled(productID string) (bool, error) {
+	return r.Monetization().IsEntitled(productID)
+}
@@ -0,0 +1,144 @@
+package skillserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func addProducts(server *alexatest.Server, count int) {
+	for i := 0; i < count; i++ {
+		server.AddProduct(alexa.InSkillProduct{
+			ProductID:   fmt.Sprintf("amzn1.adg.product.%d", i),
+			Type:        alexa.ProductEntitlement,
+			Entitled:    alexa.NotEntitled,
+			Purchasable: alexa.Purchasable,
+		})
+	}
+}
+
+func TestListProductsPage(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	addProducts(server, 5)
+	client := server.EchoRequest().Monetization()
+
+	page, err := client.ListProductsPage(alexa.ProductFilter{}, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.InSkillProducts) != 2 || !page.IsTruncated || page.NextToken == "" {
+		t.Fatalf("first page = %d products, truncated %v, next token %q", len(page.InSkillProducts), page.IsTruncated, page.NextToken)
+	}
+
+	var ids []string
+	for token := ""; ; token = page.NextToken {
+		page, err = client.ListProductsPage(alexa.ProductFilter{}, token, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, product := range page.InSkillProducts {
+			ids = append(ids, product.ProductID)
+		}
+
+		if !page.IsTruncated {
+			break
+		}
+	}
+
+	if len(ids) != 5 || ids[0] != "amzn1.adg.product.0" || ids[4] != "amzn1.adg.product.4" {
+		t.Fatalf("paged products = %v", ids)
+	}
+}
+
+func TestListProductsFollowsNextToken(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	// The stand-in returns pages of 100 products, as the API does by default.
+	addProducts(server, 250)
+	server.SetEntitled("amzn1.adg.product.240", true)
+
+	client := server.EchoRequest().Monetization()
+
+	products, err := client.ListProducts(alexa.ProductFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(products) != 250 {
+		t.Fatalf("ListProducts returned %d products, want 250", len(products))
+	}
+
+	entitled, err := client.ListProducts(alexa.ProductFilter{Entitled: alexa.Entitled})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entitled) != 1 || entitled[0].ProductID != "amzn1.adg.product.240" {
+		t.Fatalf("entitled products = %v", entitled)
+	}
+}
+
+func TestIsEntitled(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	addProducts(server, 1)
+	req := server.EchoRequest()
+
+	entitled, err := req.IsEntitled("amzn1.adg.product.0")
+	if err != nil || entitled {
+		t.Fatalf("IsEntitled before purchase = %v, %v", entitled, err)
+	}
+
+	server.SetEntitled("amzn1.adg.product.0", true)
+
+	entitled, err = req.IsEntitled("amzn1.adg.product.0")
+	if err != nil || !entitled {
+		t.Fatalf("IsEntitled after purchase = %v, %v", entitled, err)
+	}
+}
+
+func TestIsEntitledUnknownProduct(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	entitled, err := server.EchoRequest().IsEntitled("amzn1.adg.product.unknown")
+	if entitled {
+		t.Fatal("IsEntitled returned true for an unknown product")
+	}
+
+	apiErr, ok := err.(*alexa.APIError)
+	if !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("IsEntitled error = %v, want a 404 APIError", err)
+	}
+}
+
+func TestAPIClientSendsLocale(t *testing.T) {
+	var language string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language = r.Header.Get("Accept-Language")
+		w.Write([]byte(`{"inSkillProducts":[]}`))
+	}))
+	defer api.Close()
+
+	req := &alexa.EchoRequest{}
+	req.Context.System.APIEndpoint = api.URL
+	req.Request.Locale = "de-DE"
+
+	if _, err := req.Monetization().ListProducts(alexa.ProductFilter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if language != "de-DE" {
+		t.Fatalf("Accept-Language = %q, want de-DE", language)
+	}
+}
@@ -0,0 +1,378 @@
+package skillserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+)
+
+// ErrPersistenceConflict is returned by a PersistenceAdapter when the attributes being saved were
+// loaded at an older version than the one currently stored. This happens when the same user talks
+// to the skill from two devices at once.
+var ErrPersistenceConflict = errors.New("persistent attributes were changed by another request")
+
+// PersistenceAdapter is the storage backend used to keep attributes for a user across sessions.
+// Every save carries the version returned by the previous load so implementations can reject
+// writes based on stale data with ErrPersistenceConflict.
+type PersistenceAdapter interface {
+	// LoadAttributes returns the attributes and version stored for the key. A key that has never
+	// been saved returns an empty map and version 0.
+	LoadAttributes(key string) (map[string]interface{}, int64, error)
+
+	// SaveAttributes stores the attributes if the stored version still matches the provided version
+	// and returns the new version.
+	SaveAttributes(key string, attrs map[string]interface{}, version int64) (int64, error)
+
+	// DeleteAttributes removes anything stored for the key.
+	DeleteAttributes(key string) error
+}
+
+// PersistenceKeyFunc returns the key persistent attributes are stored under for a request.
+type PersistenceKeyFunc func(*EchoRequest) string
+
+// UserIDKey stores persistent attributes per Alexa account. This is the default key.
+func UserIDKey(r *EchoRequest) string {
+	return r.GetUserID()
+}
+
+// PersonIDKey stores persistent attributes per recognized speaker, falling back to the Alexa
+// account when the speaker was not recognized.
+func PersonIDKey(r *EchoRequest) string {
+	if personID := r.GetPersonID(); personID != "" {
+		return personID
+	}
+
+	return r.GetUserID()
+}
+
+type persistenceManager struct {
+	adapter  PersistenceAdapter
+	key      string
+	loaded   bool
+	attrs    map[string]interface{}
+	snapshot []byte
+	version  int64
+}
+
+func newPersistenceManager(app EchoApplication, r *EchoRequest) *persistenceManager {
+	keyFunc := app.PersistenceKey
+	if keyFunc == nil {
+		keyFunc = UserIDKey
+	}
+
+	return &persistenceManager{adapter: app.Persistence, key: keyFunc(r)}
+}
+
+func (m *persistenceManager) load() error {
+	if m.loaded {
+		return nil
+	}
+
+	attrs, version, err := m.adapter.LoadAttributes(m.key)
+	if err != nil {
+		return err
+	}
+
+	if attrs == nil {
+		attrs = make(map[string]interface{})
+	}
+
+	m.snapshot, err = json.Marshal(attrs)
+	if err != nil {
+		return err
+	}
+
+	m.attrs = attrs
+	m.version = version
+	m.loaded = true
+
+	return nil
+}
+
+func (m *persistenceManager) changed() bool {
+	if !m.loaded {
+		return false
+	}
+
+	current, err := json.Marshal(m.attrs)
+	if err != nil {
+		return true
+	}
+
+	return !bytes.Equal(current, m.snapshot)
+}
+
+func (m *persistenceManager) save() error {
+	if !m.changed() {
+		return nil
+	}
+
+	version, err := m.adapter.SaveAttributes(m.key, m.attrs, m.version)
+	if err != nil {
+		return err
+	}
+
+	m.version = version
+	m.snapshot, _ = json.Marshal(m.attrs)
+
+	return nil
+}
+
+// PersistentAttributes returns the attributes stored for the user of the request, loading them from the
+// application's PersistenceAdapter the first time it is called. The returned map can be modified
+// directly; any change is saved once the handler returns.
+func (r *EchoRequest) PersistentAttributes() (map[string]interface{}, error) {
+	if r.persistence == nil {
+		return nil, errors.New("no persistence adapter configured for this application")
+	}
+
+	if err := r.persistence.load(); err != nil {
+		return nil, err
+	}
+
+	return r.persistence.attrs, nil
+}
+
+// PersistentAttributesInto decodes the persistent attributes of the user into the struct pointed to by v.
+// Versioning works the same way as for `SessionAttributesInto`.
+func (r *EchoRequest) PersistentAttributesInto(v interface{}) error {
+	attrs, err := r.PersistentAttributes()
+	if err != nil {
+		return err
+	}
+
+	return decodeAttributes(attrs, v)
+}
+
+// SetPersistentAttributesFrom encodes the struct v into the persistent attributes of the user. The
+// attributes named by the fields of v are replaced: fields left out by omitempty are removed, so their
+// old values are not saved again. Existing attributes with other names are left in place.
+func (r *EchoRequest) SetPersistentAttributesFrom(v interface{}) error {
+	attrs, err := r.PersistentAttributes()
+	if err != nil {
+		return err
+	}
+
+	encoded, err := encodeAttributes(v)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range attributeKeys(reflect.TypeOf(v)) {
+		delete(attrs, k)
+	}
+
+	for k, val := range encoded {
+		attrs[k] = val
+	}
+
+	return nil
+}
+
+// SavePersistentAttributes saves any changes to the persistent attributes right away instead of waiting for
+// the handler to return. ErrPersistenceConflict is returned if another request saved first.
+func (r *EchoRequest) SavePersistentAttributes() error {
+	if r.persistence == nil {
+		return errors.New("no persistence adapter configured for this application")
+	}
+
+	return r.persistence.save()
+}
+
+func savePersistentAttributes(r *EchoRequest) error {
+	if r.persistence == nil {
+		return nil
+	}
+
+	return r.persistence.save()
+}
+
+// MemoryPersistence is a PersistenceAdapter that keeps attributes in memory. It is safe for concurrent
+// use but everything is lost when the process exits, which makes it most useful for development.
+type MemoryPersistence struct {
+	mu      sync.Mutex
+	records map[string]persistenceRecord
+}
+
+type persistenceRecord struct {
+	Version    int64                  `json:"version"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// NewMemoryPersistence returns an empty MemoryPersistence.
+func NewMemoryPersistence() *MemoryPersistence {
+	return &MemoryPersistence{records: map[string]persistenceRecord{}}
+}
+
+// LoadAttributes implements PersistenceAdapter.
+func (p *MemoryPersistence) LoadAttributes(key string) (map[string]interface{}, int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	record, ok := p.records[key]
+	if !ok {
+		return map[string]interface{}{}, 0, nil
+	}
+
+	attrs, err := copyAttributes(record.Attributes)
+
+	return attrs, record.Version, err
+}
+
+// SaveAttributes implements PersistenceAdapter.
+func (p *MemoryPersistence) SaveAttributes(key string, attrs map[string]interface{}, version int64) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.records[key].Version != version {
+		return 0, ErrPersistenceConflict
+	}
+
+	copied, err := copyAttributes(attrs)
+	if err != nil {
+		return 0, err
+	}
+
+	p.records[key] = persistenceRecord{Version: version + 1, Attributes: copied}
+
+	return version + 1, nil
+}
+
+// DeleteAttributes implements PersistenceAdapter.
+func (p *MemoryPersistence) DeleteAttributes(key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.records, key)
+
+	return nil
+}
+
+// FilePersistence is a PersistenceAdapter that stores the attributes of each key as a JSON file in a
+// directory. Writes are atomic, so a crash never leaves a half written file behind.
+type FilePersistence struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFilePersistence returns a FilePersistence storing its files in dir, creating it if needed.
+func NewFilePersistence(dir string) (*FilePersistence, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FilePersistence{dir: dir}, nil
+}
+
+// LoadAttributes implements PersistenceAdapter.
+func (p *FilePersistence) LoadAttributes(key string) (map[string]interface{}, int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	record, err := p.read(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return record.Attributes, record.Version, nil
+}
+
+// SaveAttributes implements PersistenceAdapter.
+func (p *FilePersistence) SaveAttributes(key string, attrs map[string]interface{}, version int64) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	record, err := p.read(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if record.Version != version {
+		return 0, ErrPersistenceConflict
+	}
+
+	contents, err := json.Marshal(persistenceRecord{Version: version + 1, Attributes: attrs})
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := ioutil.TempFile(p.dir, ".tmp-")
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), p.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+
+	return version + 1, nil
+}
+
+// DeleteAttributes implements PersistenceAdapter.
+func (p *FilePersistence) DeleteAttributes(key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := os.Remove(p.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (p *FilePersistence) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(p.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (p *FilePersistence) read(key string) (persistenceRecord, error) {
+	record := persistenceRecord{Attributes: map[string]interface{}{}}
+
+	contents, err := ioutil.ReadFile(p.path(key))
+	if os.IsNotExist(err) {
+		return record, nil
+	} else if err != nil {
+		return record, err
+	}
+
+	if err := json.Unmarshal(contents, &record); err != nil {
+		return record, err
+	}
+
+	if record.Attributes == nil {
+		record.Attributes = map[string]interface{}{}
+	}
+
+	return record, nil
+}
+
+func copyAttributes(attrs map[string]interface{}) (map[string]interface{}, error) {
+	copied := map[string]interface{}{}
+
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return copied, json.Unmarshal(raw, &copied)
+}
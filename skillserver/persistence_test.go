@@ -0,0 +1,52 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+type gameState struct {
+	Level  int    `json:"level"`
+	Streak int    `json:"streak,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+func TestSetPersistentAttributesFromReplacesFields(t *testing.T) {
+	store := alexa.NewMemoryPersistence()
+	store.SaveAttributes("amzn1.ask.account.test", map[string]interface{}{"level": 1.0, "streak": 4.0, "hint": "left", "theme": "dark"}, 0)
+
+	app := alexa.EchoApplication{
+		Persistence: store,
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			if err := req.SetPersistentAttributesFrom(&gameState{Level: 2}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+
+	req := &alexa.EchoRequest{}
+	req.Session.User.UserID = "amzn1.ask.account.test"
+	req.Request.Type = "IntentRequest"
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	saved, _, err := store.LoadAttributes("amzn1.ask.account.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved["level"] != 2.0 || saved["theme"] != "dark" {
+		t.Fatalf("saved attributes = %v, want level 2 and the theme kept", saved)
+	}
+
+	if _, ok := saved["streak"]; ok {
+		t.Fatalf("saved attributes = %v, the omitted streak was saved again", saved)
+	}
+
+	if _, ok := saved["hint"]; ok {
+		t.Fatalf("saved attributes = %v, the omitted hint was saved again", saved)
+	}
+}
@@ -0,0 +1,279 @@
+package skillserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Details about the Proactive Events API can be found on this page:
+// https://developer.amazon.com/docs/smapi/proactive-events-api.html
+
+const (
+	// DefaultAPIEndpoint is the Alexa API endpoint for North America. Skills in other regions use
+	// "https://api.eu.amazonalexa.com" or "https://api.fe.amazonalexa.com".
+	DefaultAPIEndpoint = "https://api.amazonalexa.com"
+
+	// ProactiveEventsScope is the LWA scope needed to send proactive events.
+	ProactiveEventsScope = "alexa::proactive_events"
+)
+
+// proactiveTimeLayout is the format of the times of a proactive event.
+const proactiveTimeLayout = "2006-01-02T15:04:05.00Z"
+
+const (
+	// minProactiveExpiry and maxProactiveExpiry are the shortest and longest time the Proactive Events API
+	// keeps an event for.
+	minProactiveExpiry = 5 * time.Minute
+	maxProactiveExpiry = 24 * time.Hour
+)
+
+// ProactiveEvent is a notification sent to users outside of a session. Its payload follows one of the
+// schemas of the Proactive Events API, such as the ones built by NewWeatherAlertEvent or NewOrderStatusEvent.
+type ProactiveEvent struct {
+	Timestamp           string              `json:"timestamp"`
+	ReferenceID         string              `json:"referenceId"`
+	ExpiryTime          string              `json:"expiryTime"`
+	Event               ProactiveEventBody  `json:"event"`
+	LocalizedAttributes []map[string]string `json:"localizedAttributes"`
+	RelevantAudience    ProactiveAudience   `json:"relevantAudience"`
+}
+
+// ProactiveEventBody is the schema name and payload of a proactive event.
+type ProactiveEventBody struct {
+	Name    string      `json:"name"`
+	Payload interface{} `json:"payload"`
+}
+
+// ProactiveAudience is who receives a proactive event. Type is Unicast, with the user ID in the payload,
+// or Multicast for all users subscribed to the event.
+type ProactiveAudience struct {
+	Type    string            `json:"type"`
+	Payload map[string]string `json:"payload"`
+}
+
+// NewProactiveEvent returns an event with the schema name and payload that is sent to all subscribed users
+// and expires after 24 hours. Values of the payload of the form "localizedattribute:<key>" are taken from
+// the attributes added with Localize.
+func NewProactiveEvent(name string, payload interface{}) *ProactiveEvent {
+	now := time.Now().UTC()
+
+	return &ProactiveEvent{
+		Timestamp:           now.Format(proactiveTimeLayout),
+		ReferenceID:         newReferenceID(),
+		ExpiryTime:          now.Add(24 * time.Hour).Format(proactiveTimeLayout),
+		Event:               ProactiveEventBody{Name: name, Payload: payload},
+		LocalizedAttributes: []map[string]string{},
+		RelevantAudience:    ProactiveAudience{Type: "Multicast", Payload: map[string]string{}},
+	}
+}
+
+// newReferenceID returns a random ID identifying an event, so that it can be updated by sending an event
+// with the same reference ID.
+func newReferenceID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// ToUser sends the event to a single user instead of all subscribed users.
+func (e *ProactiveEvent) ToUser(userID string) *ProactiveEvent {
+	e.RelevantAudience = ProactiveAudience{Type: "Unicast", Payload: map[string]string{"user": userID}}
+	return e
+}
+
+// ToAll sends the event to all users subscribed to it, which is the default.
+func (e *ProactiveEvent) ToAll() *ProactiveEvent {
+	e.RelevantAudience = ProactiveAudience{Type: "Multicast", Payload: map[string]string{}}
+	return e
+}
+
+// ExpiresIn sets how long the event is kept for users to hear it, between 5 minutes and 24 hours. Other
+// durations are rejected by `Validate` before the event is sent.
+func (e *ProactiveEvent) ExpiresIn(d time.Duration) *ProactiveEvent {
+	timestamp, err := time.Parse(proactiveTimeLayout, e.Timestamp)
+	if err != nil {
+		timestamp = time.Now().UTC()
+	}
+
+	e.ExpiryTime = timestamp.Add(d).Format(proactiveTimeLayout)
+	return e
+}
+
+// SetReferenceID replaces the random reference ID, e.g. to update an event sent before.
+func (e *ProactiveEvent) SetReferenceID(id string) *ProactiveEvent {
+	e.ReferenceID = id
+	return e
+}
+
+// Localize sets a localized attribute of the event for the locale.
+func (e *ProactiveEvent) Localize(locale, key, value string) *ProactiveEvent {
+	for _, attrs := range e.LocalizedAttributes {
+		if attrs["locale"] == locale {
+			attrs[key] = value
+			return e
+		}
+	}
+
+	e.LocalizedAttributes = append(e.LocalizedAttributes, map[string]string{"locale": locale, key: value})
+	return e
+}
+
+// Validate checks the event for the mistakes the Proactive Events API would reject it for: a missing name,
+// a unicast event without a user, or an expiry time that is not between 5 minutes and 24 hours after its
+// timestamp.
+func (e *ProactiveEvent) Validate() error {
+	if e.Event.Name == "" {
+		return errors.New("proactive event has no name")
+	}
+
+	if e.RelevantAudience.Type == "Unicast" && e.RelevantAudience.Payload["user"] == "" {
+		return errors.New("unicast proactive event has no user")
+	}
+
+	timestamp, err := time.Parse(proactiveTimeLayout, e.Timestamp)
+	if err != nil {
+		return errors.New("proactive event timestamp is invalid: " + err.Error())
+	}
+
+	expiry, err := time.Parse(proactiveTimeLayout, e.ExpiryTime)
+	if err != nil {
+		return errors.New("proactive event expiry time is invalid: " + err.Error())
+	}
+
+	if d := expiry.Sub(timestamp); d < minProactiveExpiry || d > maxProactiveExpiry {
+		return errors.New("proactive event must expire between 5 minutes and 24 hours after its timestamp")
+	}
+
+	return nil
+}
+
+// localizeAll sets a localized attribute for every locale in the values.
+func (e *ProactiveEvent) localizeAll(key string, values map[string]string) *ProactiveEvent {
+	for locale, value := range values {
+		e.Localize(locale, key, value)
+	}
+
+	return e
+}
+
+// NewWeatherAlertEvent returns an AMAZON.WeatherAlert.Activated event for an alert type such as TORNADO or
+// HURRICANE. The source of the alert is given per locale, e.g. {"en-US": "Weather Service"}.
+func NewWeatherAlertEvent(alertType string, source map[string]string) *ProactiveEvent {
+	payload := map[string]interface{}{
+		"weatherAlert": map[string]string{
+			"source":    "localizedattribute:source",
+			"alertType": alertType,
+		},
+	}
+
+	return NewProactiveEvent("AMAZON.WeatherAlert.Activated", payload).localizeAll("source", source)
+}
+
+// NewOrderStatusEvent returns an AMAZON.OrderStatus.Updated event for a status such as ORDER_SHIPPED or
+// ORDER_OUT_FOR_DELIVERY. The expected arrival is optional. The seller name is given per locale.
+func NewOrderStatusEvent(status string, expectedArrival time.Time, sellerName map[string]string) *ProactiveEvent {
+	state := map[string]interface{}{"status": status}
+	if !expectedArrival.IsZero() {
+		state["deliveryDetails"] = map[string]string{"expectedArrival": expectedArrival.UTC().Format(proactiveTimeLayout)}
+	}
+
+	payload := map[string]interface{}{
+		"state": state,
+		"order": map[string]interface{}{
+			"seller": map[string]string{"name": "localizedattribute:sellerName"},
+		},
+	}
+
+	return NewProactiveEvent("AMAZON.OrderStatus.Updated", payload).localizeAll("sellerName", sellerName)
+}
+
+// NewSportsEvent returns an AMAZON.SportsEvent.Updated event with the score of a game. The league name is
+// given per locale.
+func NewSportsEvent(league map[string]string, homeTeam string, homeScore int, awayTeam string, awayScore int) *ProactiveEvent {
+	payload := map[string]interface{}{
+		"sportsEvent": map[string]interface{}{
+			"eventLeague": map[string]string{"name": "localizedattribute:eventLeagueName"},
+			"homeTeamStatistic": map[string]interface{}{
+				"team":  map[string]string{"name": homeTeam},
+				"score": homeScore,
+			},
+			"awayTeamStatistic": map[string]interface{}{
+				"team":  map[string]string{"name": awayTeam},
+				"score": awayScore,
+			},
+		},
+	}
+
+	return NewProactiveEvent("AMAZON.SportsEvent.Updated", payload).localizeAll("eventLeagueName", league)
+}
+
+// NewMessageAlertEvent returns an AMAZON.MessageAlert.Activated event telling the user they have count new
+// messages from the creator. Urgency is URGENT or empty.
+func NewMessageAlertEvent(creator string, count int, urgency string) *ProactiveEvent {
+	group := map[string]interface{}{
+		"creator": map[string]string{"name": creator},
+		"count":   count,
+	}
+
+	if urgency != "" {
+		group["urgency"] = urgency
+	}
+
+	payload := map[string]interface{}{
+		"state":        map[string]string{"status": "UNREAD", "freshness": "NEW"},
+		"messageGroup": group,
+	}
+
+	return NewProactiveEvent("AMAZON.MessageAlert.Activated", payload)
+}
+
+// ProactiveEventsClient sends proactive events, authenticated with tokens from its TokenSource.
+type ProactiveEventsClient struct {
+	// TokenSource provides access tokens with the `ProactiveEventsScope` scope.
+	TokenSource TokenSource
+
+	// Endpoint is the Alexa API endpoint of the region of the users. `DefaultAPIEndpoint` is used when empty.
+	Endpoint string
+
+	// Sandbox sends events to the development stage of the skill instead of to live users.
+	Sandbox bool
+
+	// HTTPClient is used to make the requests. A client with a 10 second timeout is used when nil.
+	HTTPClient *http.Client
+}
+
+// NewProactiveEventsClient returns a client authenticated with the client ID and secret of the skill, found
+// on the Permissions page of the developer console. Events are sent to the development stage of the skill
+// when sandbox is true, and to live users otherwise.
+func NewProactiveEventsClient(clientID, clientSecret string, sandbox bool) *ProactiveEventsClient {
+	return &ProactiveEventsClient{
+		TokenSource: NewLWATokenSource(clientID, clientSecret, ProactiveEventsScope),
+		Sandbox:     sandbox,
+	}
+}
+
+// Send validates and sends the event. The API accepts events asynchronously, so users may receive them a
+// little later.
+func (c *ProactiveEventsClient) Send(event *ProactiveEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+
+	path := "/v1/proactiveEvents"
+	if c.Sandbox {
+		path += "/stages/development"
+	}
+
+	api := &APIClient{Endpoint: endpoint, TokenSource: c.TokenSource, HTTPClient: c.HTTPClient}
+
+	return api.Do("POST", path, nil, event, nil)
+}
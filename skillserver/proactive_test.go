@@ -0,0 +1,129 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestProactiveEventsTokenCached(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.ProactiveEventsClient(false)
+
+	for i := 0; i < 3; i++ {
+		event := alexa.NewWeatherAlertEvent("TORNADO", map[string]string{"en-US": "Weather Service"})
+		if err := client.Send(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := server.TokenRequests(); n != 1 {
+		t.Fatalf("%d token requests for 3 events, want 1", n)
+	}
+
+	if n := len(server.ProactiveEvents(false)); n != 3 {
+		t.Fatalf("%d events received, want 3", n)
+	}
+}
+
+func TestProactiveEventsTokenRefreshed(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetTokenLifetime(time.Second)
+
+	client := server.ProactiveEventsClient(false)
+	client.TokenSource.(*alexa.LWATokenSource).RefreshBefore = time.Millisecond
+
+	event := alexa.NewMessageAlertEvent("Jane", 2, "")
+
+	if err := client.Send(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Send(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := server.TokenRequests(); n != 1 {
+		t.Fatalf("%d token requests before expiry, want 1", n)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	if err := client.Send(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := server.TokenRequests(); n != 2 {
+		t.Fatalf("%d token requests after expiry, want 2", n)
+	}
+}
+
+func TestProactiveEventsStages(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	development := alexa.NewOrderStatusEvent("ORDER_SHIPPED", time.Time{}, map[string]string{"en-US": "Shop"})
+	if err := server.ProactiveEventsClient(true).Send(development); err != nil {
+		t.Fatal(err)
+	}
+
+	live := alexa.NewSportsEvent(map[string]string{"en-US": "League"}, "Home", 2, "Away", 1).ToUser("amzn1.ask.account.test")
+	if err := server.ProactiveEventsClient(false).Send(live); err != nil {
+		t.Fatal(err)
+	}
+
+	sandboxEvents := server.ProactiveEvents(true)
+	if len(sandboxEvents) != 1 || sandboxEvents[0].Event.Name != "AMAZON.OrderStatus.Updated" {
+		t.Fatalf("development events = %+v", sandboxEvents)
+	}
+
+	liveEvents := server.ProactiveEvents(false)
+	if len(liveEvents) != 1 || liveEvents[0].Event.Name != "AMAZON.SportsEvent.Updated" {
+		t.Fatalf("live events = %+v", liveEvents)
+	}
+
+	if liveEvents[0].RelevantAudience.Type != "Unicast" || liveEvents[0].RelevantAudience.Payload["user"] != "amzn1.ask.account.test" {
+		t.Fatalf("live event audience = %+v", liveEvents[0].RelevantAudience)
+	}
+}
+
+func TestProactiveEventValidate(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.ProactiveEventsClient(false)
+
+	tests := []struct {
+		name  string
+		event *alexa.ProactiveEvent
+		valid bool
+	}{
+		{"default expiry", alexa.NewMessageAlertEvent("Jane", 1, "URGENT"), true},
+		{"shortest expiry", alexa.NewMessageAlertEvent("Jane", 1, "").ExpiresIn(5 * time.Minute), true},
+		{"expiry too short", alexa.NewMessageAlertEvent("Jane", 1, "").ExpiresIn(time.Minute), false},
+		{"expiry too long", alexa.NewMessageAlertEvent("Jane", 1, "").ExpiresIn(25 * time.Hour), false},
+		{"no name", alexa.NewProactiveEvent("", map[string]string{}), false},
+		{"unicast without user", alexa.NewMessageAlertEvent("Jane", 1, "").ToUser(""), false},
+	}
+
+	for _, test := range tests {
+		err := client.Send(test.event)
+		if test.valid && err != nil {
+			t.Errorf("%s: Send returned %v", test.name, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%s: Send accepted an invalid event", test.name)
+		}
+	}
+
+	if n := len(server.ProactiveEvents(false)); n != 2 {
+		t.Fatalf("%d events reached the API, want only the 2 valid ones", n)
+	}
+}
@@ -0,0 +1,96 @@
+package skillserver
+
+// Details about the Customer Profile API can be found on this page:
+// https://developer.amazon.com/docs/custom-skills/request-customer-contact-information-for-use-in-your-skill.html
+
+const (
+	// NameScope is the permission needed to read the full name of the customer or person.
+	NameScope = "alexa::profile:name:read"
+
+	// GivenNameScope is the permission needed to read the given name of the customer or person.
+	GivenNameScope = "alexa::profile:given_name:read"
+
+	// EmailScope is the permission needed to read the email address of the customer.
+	EmailScope = "alexa::profile:email:read"
+
+	// MobileNumberScope is the permission needed to read the mobile number of the customer or person.
+	MobileNumberScope = "alexa::profile:mobile_number:read"
+)
+
+// MobileNumber is a phone number split into its country calling code and the rest of the number.
+type MobileNumber struct {
+	CountryCode string `json:"countryCode"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// Profile holds the contact information of a customer or recognized person.
+type Profile struct {
+	Name         string
+	GivenName    string
+	Email        string
+	MobileNumber *MobileNumber
+}
+
+// ProfileClient reads contact information through the Customer Profile API, either for the account of
+// the customer or for the person recognized by voice. Every method returns ErrPermissionMissing if the
+// user has not granted the matching scope.
+type ProfileClient struct {
+	api  *APIClient
+	path string
+}
+
+// NewCustomerProfileClient returns a ProfileClient for the account the access token of the API client belongs to.
+func NewCustomerProfileClient(api *APIClient) *ProfileClient {
+	return &ProfileClient{api: api, path: "/v2/accounts/~current/settings/Profile."}
+}
+
+// NewPersonProfileClient returns a ProfileClient for the person recognized in the request the access token
+// of the API client came from.
+func NewPersonProfileClient(api *APIClient) *ProfileClient {
+	return &ProfileClient{api: api, path: "/v2/persons/~current/profile/"}
+}
+
+// CustomerProfile returns a ProfileClient for the account of the user of the request.
+func (r *EchoRequest) CustomerProfile() *ProfileClient {
+	return NewCustomerProfileClient(r.APIClient())
+}
+
+// PersonProfile returns a ProfileClient for the person recognized in the request. Check `GetPersonID` first,
+// as the API can only be used when the speaker was recognized.
+func (r *EchoRequest) PersonProfile() *ProfileClient {
+	return NewPersonProfileClient(r.APIClient())
+}
+
+// Name returns the full name.
+func (c *ProfileClient) Name() (string, error) {
+	return c.getString("name")
+}
+
+// GivenName returns the given name.
+func (c *ProfileClient) GivenName() (string, error) {
+	return c.getString("givenName")
+}
+
+// Email returns the email address. It is only available for the customer, not for a recognized person.
+func (c *ProfileClient) Email() (string, error) {
+	return c.getString("email")
+}
+
+// MobileNumber returns the mobile number.
+func (c *ProfileClient) MobileNumber() (*MobileNumber, error) {
+	number := &MobileNumber{}
+	if err := c.api.Do("GET", c.path+"mobileNumber", nil, nil, number); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return number, nil
+}
+
+func (c *ProfileClient) getString(field string) (string, error) {
+	var value string
+	if err := c.api.Do("GET", c.path+field, nil, nil, &value); err != nil {
+		return "", permissionError(err)
+	}
+
+	return value, nil
+}
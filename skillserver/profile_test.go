@@ -0,0 +1,74 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestCustomerProfile(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetCustomerProfile(alexa.Profile{
+		Name:         "Jane Doe",
+		GivenName:    "Jane",
+		Email:        "jane@example.com",
+		MobileNumber: &alexa.MobileNumber{CountryCode: "+1", PhoneNumber: "5555550100"},
+	})
+
+	profile := server.EchoRequest().CustomerProfile()
+
+	if name, err := profile.Name(); err != nil || name != "Jane Doe" {
+		t.Fatalf("Name = %q, %v", name, err)
+	}
+
+	if givenName, err := profile.GivenName(); err != nil || givenName != "Jane" {
+		t.Fatalf("GivenName = %q, %v", givenName, err)
+	}
+
+	if email, err := profile.Email(); err != nil || email != "jane@example.com" {
+		t.Fatalf("Email = %q, %v", email, err)
+	}
+
+	number, err := profile.MobileNumber()
+	if err != nil || number.CountryCode != "+1" || number.PhoneNumber != "5555550100" {
+		t.Fatalf("MobileNumber = %v, %v", number, err)
+	}
+}
+
+func TestCustomerProfilePermissionMissing(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetCustomerProfile(alexa.Profile{Name: "Jane Doe", Email: "jane@example.com"})
+	server.SetPermission(alexa.EmailScope, false)
+
+	profile := server.EchoRequest().CustomerProfile()
+
+	if _, err := profile.Email(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("Email without permission returned %v, want ErrPermissionMissing", err)
+	}
+
+	if name, err := profile.Name(); err != nil || name != "Jane Doe" {
+		t.Fatalf("Name = %q, %v", name, err)
+	}
+}
+
+func TestPersonProfile(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	profile := server.EchoRequest().PersonProfile()
+
+	if _, err := profile.GivenName(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("GivenName without a recognized person returned %v, want ErrPermissionMissing", err)
+	}
+
+	server.SetPersonProfile(alexa.Profile{GivenName: "Sam"})
+
+	if givenName, err := profile.GivenName(); err != nil || givenName != "Sam" {
+		t.Fatalf("GivenName = %q, %v", givenName, err)
+	}
+}
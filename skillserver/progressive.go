@@ -0,0 +1,104 @@
+package skillserver
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Details about progressive responses can be found on this page:
+// https://developer.amazon.com/docs/custom-skills/send-the-user-a-progressive-response.html
+
+// DefaultProgressiveSpeech is spoken by the automatic progressive response of an EchoApplication when it
+// doesn't set its own ProgressiveResponseSpeech.
+const DefaultProgressiveSpeech = "One moment."
+
+// progressiveHTTPClient sends the automatic progressive response. The response of the skill waits for it,
+// so its timeout is kept well below the 8 seconds the Alexa service waits for a skill.
+var progressiveHTTPClient = &http.Client{Timeout: 2 * time.Second}
+
+// ProgressiveResponse is the body sent to the Directive Service to speak while the skill prepares its response.
+type ProgressiveResponse struct {
+	Header struct {
+		RequestID string `json:"requestId"`
+	} `json:"header"`
+	Directive struct {
+		Type   string `json:"type"`
+		Speech string `json:"speech"`
+	} `json:"directive"`
+}
+
+// DirectiveClient sends progressive responses through the Directive Service API.
+type DirectiveClient struct {
+	api *APIClient
+}
+
+// NewDirectiveClient returns a DirectiveClient using the API client.
+func NewDirectiveClient(api *APIClient) *DirectiveClient {
+	return &DirectiveClient{api: api}
+}
+
+// Directives returns a DirectiveClient for the request.
+func (r *EchoRequest) Directives() *DirectiveClient {
+	return NewDirectiveClient(r.APIClient())
+}
+
+// Speak has Alexa speak the plain text or SSML while the request with the ID is still being handled.
+func (c *DirectiveClient) Speak(requestID, speech string) error {
+	body := ProgressiveResponse{}
+	body.Header.RequestID = requestID
+	body.Directive.Type = "VoicePlayer.Speak"
+	body.Directive.Speech = speech
+
+	return c.api.Do("POST", "/v1/directives", nil, body, nil)
+}
+
+// SendProgressiveResponse is a convenience method for speaking the plain text or SSML to the user while
+// a slow handler is still working on the response. It can be called from LaunchRequest and IntentRequest handlers.
+func (r *EchoRequest) SendProgressiveResponse(speech string) error {
+	return r.Directives().Speak(r.Request.RequestID, speech)
+}
+
+// startProgressiveResponse speaks the progressive response of the application if the handlers of the request
+// are still running after its ProgressiveResponseAfter. The returned function stops the timer and waits for a
+// progressive response that is already being sent, as it must reach the Alexa service before the response.
+// That wait is bounded by the timeout of progressiveHTTPClient.
+func startProgressiveResponse(app EchoApplication, r *EchoRequest) func() {
+	if app.ProgressiveResponseAfter <= 0 {
+		return func() {}
+	}
+
+	if r.GetRequestType() != "LaunchRequest" && r.GetRequestType() != "IntentRequest" {
+		return func() {}
+	}
+
+	speech := app.ProgressiveResponseSpeech
+	if speech == "" {
+		speech = DefaultProgressiveSpeech
+	}
+
+	api := r.APIClient()
+	api.HTTPClient = progressiveHTTPClient
+	client := NewDirectiveClient(api)
+	requestID := r.Request.RequestID
+
+	var speaking sync.WaitGroup
+	speaking.Add(1)
+
+	timer := time.AfterFunc(app.ProgressiveResponseAfter, func() {
+		defer speaking.Done()
+
+		if err := client.Speak(requestID, speech); err != nil {
+			log.Println("Could not send progressive response: " + err.Error())
+		}
+	})
+
+	return func() {
+		if timer.Stop() {
+			speaking.Done()
+		}
+
+		speaking.Wait()
+	}
+}
@@ -0,0 +1,99 @@
+package skillserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestProgressiveResponseAfterThreshold(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	app := alexa.EchoApplication{
+		ProgressiveResponseAfter:  20 * time.Millisecond,
+		ProgressiveResponseSpeech: "Looking that up.",
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			time.Sleep(100 * time.Millisecond)
+			resp.OutputSpeech("Done.")
+		},
+	}
+
+	req := server.EchoRequest()
+	req.Request.Type = "IntentRequest"
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	// The progressive response has been sent by the time the response is written.
+	sent := server.ProgressiveResponses()
+	if len(sent) != 1 {
+		t.Fatalf("%d progressive responses sent, want 1", len(sent))
+	}
+
+	if sent[0].Header.RequestID != req.Request.RequestID || sent[0].Directive.Speech != "Looking that up." {
+		t.Fatalf("progressive response = %+v", sent[0])
+	}
+}
+
+func TestProgressiveResponseBeforeThreshold(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	app := alexa.EchoApplication{
+		ProgressiveResponseAfter: 200 * time.Millisecond,
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			resp.OutputSpeech("Done.")
+		},
+	}
+
+	req := server.EchoRequest()
+	req.Request.Type = "IntentRequest"
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	if sent := server.ProgressiveResponses(); len(sent) != 0 {
+		t.Fatalf("%d progressive responses sent for a fast handler, want 0", len(sent))
+	}
+}
+
+func TestProgressiveResponseWaitIsBounded(t *testing.T) {
+	hang := make(chan struct{})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	defer api.Close()
+	defer close(hang)
+
+	app := alexa.EchoApplication{
+		ProgressiveResponseAfter: 10 * time.Millisecond,
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			time.Sleep(50 * time.Millisecond)
+			resp.OutputSpeech("Done.")
+		},
+	}
+
+	req := &alexa.EchoRequest{}
+	req.Request.Type = "IntentRequest"
+	req.Request.RequestID = "amzn1.echo-api.request.test"
+	req.Context.System.APIEndpoint = api.URL
+	req.Context.System.APIAccessToken = "test-token"
+
+	start := time.Now()
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("response took %v waiting for a hanging progressive response", elapsed)
+	}
+}
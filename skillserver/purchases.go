@@ -0,0 +1,199 @@
+package skillserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Details about in-skill purchasing can be found on this page:
+// https://developer.amazon.com/docs/in-skill-purchase/add-isps-to-a-skill.html
+
+// PurchaseResult is the outcome of a purchase flow reported in a Connections.Response request.
+type PurchaseResult string
+
+const (
+	// PurchaseAccepted means the user bought the product or accepted the cancellation.
+	PurchaseAccepted PurchaseResult = "ACCEPTED"
+
+	// PurchaseDeclined means the user decided not to buy the product.
+	PurchaseDeclined PurchaseResult = "DECLINED"
+
+	// PurchaseAlreadyPurchased means the user already owns the product.
+	PurchaseAlreadyPurchased PurchaseResult = "ALREADY_PURCHASED"
+
+	// PurchaseError means the purchase flow could not be completed.
+	PurchaseError PurchaseResult = "ERROR"
+)
+
+// connectionsTokenSeparator separates the token given to a Connections.SendRequest builder from the session
+// attributes stashed behind it. It is added to every token, so the last one in a token is always the one the
+// library wrote, whatever the token of the skill contains. The stashed part is base64url encoded and never
+// contains it.
+const connectionsTokenSeparator = "~sa~"
+
+// maxStashedAttributesSize is the largest encoded size of the session attributes stashed in a Connections
+// token. Skills with more state should keep it in a SessionStore or in persistent attributes.
+const maxStashedAttributesSize = 4096
+
+// EchoConnectionsStatus is the status of a Connections.Response request.
+type EchoConnectionsStatus struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// EchoPurchasePayload is the payload of a Connections.Response request for a Buy, Upsell or Cancel request.
+type EchoPurchasePayload struct {
+	PurchaseResult PurchaseResult `json:"purchaseResult"`
+	ProductID      string         `json:"productId"`
+	Message        string         `json:"message,omitempty"`
+}
+
+// ConnectionsSendRequestDirective starts a Buy, Upsell or Cancel purchase flow for an in-skill product.
+// The session ends once the directive is sent; the result arrives later as a Connections.Response request.
+type ConnectionsSendRequestDirective struct {
+	Type    string                 `json:"type"`
+	Name    string                 `json:"name"`
+	Payload map[string]interface{} `json:"payload"`
+	Token   string                 `json:"token"`
+}
+
+// DirectiveType implements Directive.
+func (d *ConnectionsSendRequestDirective) DirectiveType() string {
+	return d.Type
+}
+
+// BuyProduct starts the purchase flow for the product. The token is returned in the Connections.Response request.
+func (r *EchoResponse) BuyProduct(productID, token string) *EchoResponse {
+	return r.sendPurchaseRequest("Buy", productID, "", token)
+}
+
+// UpsellProduct offers the product to the user with the upsell message before starting the purchase flow.
+func (r *EchoResponse) UpsellProduct(productID, upsellMessage, token string) *EchoResponse {
+	return r.sendPurchaseRequest("Upsell", productID, upsellMessage, token)
+}
+
+// CancelProduct starts the flow that cancels a subscription or returns an entitlement the user bought.
+func (r *EchoResponse) CancelProduct(productID, token string) *EchoResponse {
+	return r.sendPurchaseRequest("Cancel", productID, "", token)
+}
+
+func (r *EchoResponse) sendPurchaseRequest(name, productID, upsellMessage, token string) *EchoResponse {
+	payload := map[string]interface{}{
+		"InSkillProduct": map[string]string{"productId": productID},
+	}
+
+	if upsellMessage != "" {
+		payload["upsellMessage"] = upsellMessage
+	}
+
+	return r.AddDirective(&ConnectionsSendRequestDirective{
+		Type:    "Connections.SendRequest",
+		Name:    name,
+		Payload: payload,
+		Token:   token,
+	}).EndSession(true)
+}
+
+// GetPurchaseResult is a convenience method for getting the result of a purchase flow out of a
+// Connections.Response request.
+func (r *EchoRequest) GetPurchaseResult() (*EchoPurchasePayload, error) {
+	if r.GetRequestType() != "Connections.Response" || len(r.Request.Payload) == 0 {
+		return nil, errors.New("request is not a Connections.Response")
+	}
+
+	var payload EchoPurchasePayload
+	if err := json.Unmarshal(r.Request.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+// stashSessionAttributes adds the session attributes of the response to the token of each
+// Connections.SendRequest directive, as the purchase flow ends the session and the result arrives in a
+// new one. Protected attributes are sealed for the user, since the session ID changes. The separator is
+// added even when there are no attributes to stash. An error is returned when the attributes are larger
+// than maxStashedAttributesSize.
+func stashSessionAttributes(req *EchoRequest, resp *EchoResponse, codec *AttributeCodec) error {
+	for _, directive := range resp.Response.Directives {
+		d, ok := directive.(*ConnectionsSendRequestDirective)
+		if !ok {
+			continue
+		}
+
+		encoded := ""
+		if len(resp.SessionAttributes) > 0 {
+			attrs, err := copyAttributes(resp.SessionAttributes)
+			if err != nil {
+				return err
+			}
+
+			if codec != nil {
+				if err := codec.Seal(req.GetUserID(), attrs); err != nil {
+					return err
+				}
+			}
+
+			raw, err := json.Marshal(attrs)
+			if err != nil {
+				return err
+			}
+
+			encoded = base64.RawURLEncoding.EncodeToString(raw)
+			if len(encoded) > maxStashedAttributesSize {
+				return fmt.Errorf("session attributes are %d bytes encoded, more than the %d that can be stashed in a Connections token", len(encoded), maxStashedAttributesSize)
+			}
+		}
+
+		d.Token += connectionsTokenSeparator + encoded
+	}
+
+	return nil
+}
+
+// restoreSessionAttributes puts the session attributes stashed by stashSessionAttributes back into the
+// Connections.Response request and restores the token the skill originally provided. An error is returned
+// when the stashed attributes can't be decoded.
+func restoreSessionAttributes(req *EchoRequest, codec *AttributeCodec) error {
+	i := strings.LastIndex(req.Request.Token, connectionsTokenSeparator)
+	if i < 0 {
+		return nil
+	}
+
+	stashed := req.Request.Token[i+len(connectionsTokenSeparator):]
+	req.Request.Token = req.Request.Token[:i]
+	if stashed == "" {
+		return nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(stashed)
+	if err != nil {
+		return errors.New("could not decode stashed session attributes: " + err.Error())
+	}
+
+	attrs := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return errors.New("could not decode stashed session attributes: " + err.Error())
+	}
+
+	if codec != nil {
+		if err := codec.Open(req.GetUserID(), attrs); err != nil {
+			return err
+		}
+	}
+
+	if req.Session.Attributes == nil {
+		req.Session.Attributes = make(map[string]interface{})
+	}
+
+	for k, v := range attrs {
+		if _, ok := req.Session.Attributes[k]; !ok {
+			req.Session.Attributes[k] = v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package skillserver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+// purchaseToken sends an IntentRequest through the application and returns the token of the
+// Connections.SendRequest directive in the response.
+func purchaseToken(t *testing.T, app alexa.EchoApplication) string {
+	req := &alexa.EchoRequest{}
+	req.Session.SessionID = "amzn1.echo-api.session.buy"
+	req.Session.User.UserID = "amzn1.ask.account.test"
+	req.Request.Type = "IntentRequest"
+	req.Request.Intent.Name = "BuyHints"
+
+	w := alexa.ServeEcho(app, req)
+	if w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Response struct {
+			Directives []struct {
+				Token string `json:"token"`
+			} `json:"directives"`
+		} `json:"response"`
+	}
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Response.Directives) != 1 {
+		t.Fatalf("%d directives, want 1", len(resp.Response.Directives))
+	}
+
+	return resp.Response.Directives[0].Token
+}
+
+// purchaseResult sends the Connections.Response for the token through the application.
+func purchaseResult(t *testing.T, app alexa.EchoApplication, token string) {
+	req := &alexa.EchoRequest{}
+	req.Session.SessionID = "amzn1.echo-api.session.result"
+	req.Session.User.UserID = "amzn1.ask.account.test"
+	req.Context.System.User.UserID = "amzn1.ask.account.test"
+	req.Request.Type = "Connections.Response"
+	req.Request.Name = "Buy"
+	req.Request.Token = token
+	req.Request.Payload = []byte(`{"purchaseResult":"ACCEPTED","productId":"hints"}`)
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPurchaseRestoresTokenAndAttributes(t *testing.T) {
+	tokens := []string{"order~sa~42", "order", ""}
+
+	for _, skillToken := range tokens {
+		var gotToken string
+		var gotAttrs map[string]interface{}
+
+		app := alexa.EchoApplication{
+			OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+				resp.SessionAttributes["level"] = 3.0
+				resp.BuyProduct("hints", skillToken)
+			},
+			OnConnectionsResponse: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+				gotToken = req.Request.Token
+				gotAttrs = req.Session.Attributes
+			},
+		}
+
+		purchaseResult(t, app, purchaseToken(t, app))
+
+		if gotToken != skillToken || gotAttrs["level"] != 3.0 {
+			t.Errorf("token %q: restored token %q and attributes %v", skillToken, gotToken, gotAttrs)
+		}
+	}
+}
+
+func TestPurchaseWithoutAttributesRestoresToken(t *testing.T) {
+	var gotToken string
+
+	app := alexa.EchoApplication{
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			resp.BuyProduct("hints", "order~sa~42")
+		},
+		OnConnectionsResponse: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			gotToken = req.Request.Token
+		},
+	}
+
+	purchaseResult(t, app, purchaseToken(t, app))
+
+	if gotToken != "order~sa~42" {
+		t.Fatalf("restored token %q, want the token of the skill", gotToken)
+	}
+}
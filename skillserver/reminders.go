@@ -0,0 +1,307 @@
+package skillserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Details about the Reminders API can be found on this page:
+// https://developer.amazon.com/docs/smapi/alexa-reminders-api-reference.html
+
+// RemindersScope is the permission needed to create and manage reminders for the user.
+const RemindersScope = "alexa::alerts:reminders:skill:readwrite"
+
+const (
+	remindersPath = "/v1/alerts/reminders"
+
+	// reminderTimeLayout is the format of the local date and time values of the Reminders API.
+	reminderTimeLayout = "2006-01-02T15:04:05.000"
+)
+
+var (
+	// ErrMaxRemindersExceeded is returned when the user already has the maximum number of reminders for the skill.
+	ErrMaxRemindersExceeded = errors.New("the user has reached the maximum number of reminders")
+
+	// ErrReminderNotFound is returned when no reminder exists for the alert token.
+	ErrReminderNotFound = errors.New("reminder not found")
+
+	// ErrRemindersNotSupported is returned when the device of the user can't play reminders.
+	ErrRemindersNotSupported = errors.New("the device does not support reminders")
+)
+
+// ReminderTriggerType selects when a reminder goes off.
+type ReminderTriggerType string
+
+const (
+	// TriggerAbsolute reminders go off at a date and time in a time zone.
+	TriggerAbsolute ReminderTriggerType = "SCHEDULED_ABSOLUTE"
+
+	// TriggerRelative reminders go off a number of seconds after they were created.
+	TriggerRelative ReminderTriggerType = "SCHEDULED_RELATIVE"
+)
+
+// ReminderTrigger is the time a reminder goes off. ScheduledTime is a local time without offset, interpreted
+// in the time zone named by TimeZoneID, or in the time zone of the device when TimeZoneID is empty.
+type ReminderTrigger struct {
+	Type            ReminderTriggerType `json:"type"`
+	ScheduledTime   string              `json:"scheduledTime,omitempty"`
+	TimeZoneID      string              `json:"timeZoneId,omitempty"`
+	OffsetInSeconds int                 `json:"offsetInSeconds,omitempty"`
+	Recurrence      *ReminderRecurrence `json:"recurrence,omitempty"`
+}
+
+// ReminderRecurrence repeats an absolute reminder following iCalendar recurrence rules, such as the ones
+// built by DailyRule and WeeklyRule.
+type ReminderRecurrence struct {
+	StartDateTime   string   `json:"startDateTime,omitempty"`
+	EndDateTime     string   `json:"endDateTime,omitempty"`
+	RecurrenceRules []string `json:"recurrenceRules"`
+}
+
+// Reminder is a reminder as it is sent to and returned by the Reminders API. The alert token, times, status
+// and version are filled in by the API.
+type Reminder struct {
+	AlertToken       string                   `json:"alertToken,omitempty"`
+	RequestTime      string                   `json:"requestTime,omitempty"`
+	CreatedTime      string                   `json:"createdTime,omitempty"`
+	UpdatedTime      string                   `json:"updatedTime,omitempty"`
+	Status           string                   `json:"status,omitempty"`
+	Version          string                   `json:"version,omitempty"`
+	Trigger          *ReminderTrigger         `json:"trigger,omitempty"`
+	AlertInfo        ReminderAlertInfo        `json:"alertInfo"`
+	PushNotification ReminderPushNotification `json:"pushNotification"`
+}
+
+// ReminderAlertInfo holds what Alexa says and shows when a reminder goes off.
+type ReminderAlertInfo struct {
+	SpokenInfo ReminderSpokenInfo `json:"spokenInfo"`
+}
+
+// ReminderSpokenInfo holds the content of a reminder for each locale.
+type ReminderSpokenInfo struct {
+	Content []ReminderContent `json:"content"`
+}
+
+// ReminderContent is the content of a reminder in a single locale. Text is shown in the Alexa app and on
+// screens, and spoken unless SSML is set.
+type ReminderContent struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text"`
+	SSML   string `json:"ssml,omitempty"`
+}
+
+// ReminderPushNotification sets whether a push notification is sent to the Alexa app. Status is ENABLED or DISABLED.
+type ReminderPushNotification struct {
+	Status string `json:"status"`
+}
+
+// AbsoluteTrigger returns a trigger going off at the time. The time zone is taken from the location of the
+// time, or from the device when it is time.Local.
+func AbsoluteTrigger(at time.Time) *ReminderTrigger {
+	trigger := &ReminderTrigger{Type: TriggerAbsolute, ScheduledTime: at.Format(reminderTimeLayout)}
+	if at.Location() != time.Local {
+		trigger.TimeZoneID = at.Location().String()
+	}
+
+	return trigger
+}
+
+// RelativeTrigger returns a trigger going off the duration after the reminder is created.
+func RelativeTrigger(after time.Duration) *ReminderTrigger {
+	return &ReminderTrigger{Type: TriggerRelative, OffsetInSeconds: int(after / time.Second)}
+}
+
+// SlotTrigger returns an absolute trigger for the values of an AMAZON.DATE and an AMAZON.TIME slot. The date
+// must name a single day, and the time can be a time of day such as "17:30" or one of the periods MO, AF, EV
+// and NI. An empty timeZoneID uses the time zone of the device.
+func SlotTrigger(date, timeOfDay EchoSlot, timeZoneID string) (*ReminderTrigger, error) {
+	day, err := time.Parse("2006-01-02", date.Value)
+	if err != nil {
+		return nil, fmt.Errorf("date slot value %q does not name a single day", date.Value)
+	}
+
+	clock, err := parseSlotTime(timeOfDay.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	at := day.Add(clock)
+
+	return &ReminderTrigger{Type: TriggerAbsolute, ScheduledTime: at.Format(reminderTimeLayout), TimeZoneID: timeZoneID}, nil
+}
+
+// slotTimePeriods are the times used for the parts of the day an AMAZON.TIME slot can hold.
+var slotTimePeriods = map[string]time.Duration{
+	"MO": 9 * time.Hour,
+	"AF": 14 * time.Hour,
+	"EV": 19 * time.Hour,
+	"NI": 21 * time.Hour,
+}
+
+// parseSlotTime returns the time since midnight of an AMAZON.TIME slot value.
+func parseSlotTime(value string) (time.Duration, error) {
+	if period, ok := slotTimePeriods[value]; ok {
+		return period, nil
+	}
+
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+
+	return 0, fmt.Errorf("time slot value %q is not a time of day", value)
+}
+
+// Recur repeats the reminder following the recurrence rules.
+func (t *ReminderTrigger) Recur(rules ...string) *ReminderTrigger {
+	t.Recurrence = &ReminderRecurrence{RecurrenceRules: rules}
+	return t
+}
+
+// RecurBetween repeats the reminder following the recurrence rules from start until end. Either can be the
+// zero time to leave that side open.
+func (t *ReminderTrigger) RecurBetween(start, end time.Time, rules ...string) *ReminderTrigger {
+	t.Recur(rules...)
+
+	if !start.IsZero() {
+		t.Recurrence.StartDateTime = start.Format(reminderTimeLayout)
+	}
+
+	if !end.IsZero() {
+		t.Recurrence.EndDateTime = end.Format(reminderTimeLayout)
+	}
+
+	return t
+}
+
+// DailyRule returns a recurrence rule going off every day at the hour and minute.
+func DailyRule(hour, minute int) string {
+	return fmt.Sprintf("FREQ=DAILY;BYHOUR=%d;BYMINUTE=%d;BYSECOND=0;INTERVAL=1", hour, minute)
+}
+
+// WeeklyRule returns a recurrence rule going off on the days of the week at the hour and minute.
+func WeeklyRule(hour, minute int, days ...time.Weekday) string {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = strings.ToUpper(day.String()[:2])
+	}
+
+	return fmt.Sprintf("FREQ=WEEKLY;BYDAY=%s;BYHOUR=%d;BYMINUTE=%d;BYSECOND=0;INTERVAL=1", strings.Join(names, ","), hour, minute)
+}
+
+// NewReminder returns a reminder going off at the trigger with push notifications enabled. Add its content
+// with AddContent or AddSSMLContent.
+func NewReminder(trigger *ReminderTrigger) *Reminder {
+	return &Reminder{Trigger: trigger, PushNotification: ReminderPushNotification{Status: "ENABLED"}}
+}
+
+// AddContent adds the text of the reminder for the locale.
+func (r *Reminder) AddContent(locale, text string) *Reminder {
+	r.AlertInfo.SpokenInfo.Content = append(r.AlertInfo.SpokenInfo.Content, ReminderContent{Locale: locale, Text: text})
+	return r
+}
+
+// AddSSMLContent adds content for the locale that shows the text and speaks the SSML.
+func (r *Reminder) AddSSMLContent(locale, text string, builder *SSMLTextBuilder) *Reminder {
+	r.AlertInfo.SpokenInfo.Content = append(r.AlertInfo.SpokenInfo.Content, ReminderContent{Locale: locale, Text: text, SSML: builder.Build()})
+	return r
+}
+
+// DisablePushNotification stops a notification from being sent to the Alexa app for the reminder.
+func (r *Reminder) DisablePushNotification() *Reminder {
+	r.PushNotification.Status = "DISABLED"
+	return r
+}
+
+// RemindersClient creates and manages the reminders the skill set for the user. Every method returns
+// ErrPermissionMissing if the user has not granted the RemindersScope permission.
+type RemindersClient struct {
+	api *APIClient
+}
+
+// NewRemindersClient returns a RemindersClient using the API client.
+func NewRemindersClient(api *APIClient) *RemindersClient {
+	return &RemindersClient{api: api}
+}
+
+// Reminders returns a RemindersClient for the user of the request.
+func (r *EchoRequest) Reminders() *RemindersClient {
+	return NewRemindersClient(r.APIClient())
+}
+
+// Create creates the reminder and returns it with the alert token and status set by the API.
+func (c *RemindersClient) Create(reminder *Reminder) (*Reminder, error) {
+	return c.send("POST", remindersPath, reminder)
+}
+
+// Update replaces the reminder with the alert token.
+func (c *RemindersClient) Update(alertToken string, reminder *Reminder) (*Reminder, error) {
+	return c.send("PUT", remindersPath+"/"+url.PathEscape(alertToken), reminder)
+}
+
+// Get returns the reminder with the alert token.
+func (c *RemindersClient) Get(alertToken string) (*Reminder, error) {
+	reminder := &Reminder{}
+	if err := c.api.Do("GET", remindersPath+"/"+url.PathEscape(alertToken), nil, nil, reminder); err != nil {
+		return nil, reminderError(err)
+	}
+
+	return reminder, nil
+}
+
+// List returns all reminders the skill set for the user.
+func (c *RemindersClient) List() ([]Reminder, error) {
+	var list struct {
+		Alerts []Reminder `json:"alerts"`
+	}
+
+	if err := c.api.Do("GET", remindersPath, nil, nil, &list); err != nil {
+		return nil, reminderError(err)
+	}
+
+	return list.Alerts, nil
+}
+
+// Delete deletes the reminder with the alert token.
+func (c *RemindersClient) Delete(alertToken string) error {
+	return reminderError(c.api.Do("DELETE", remindersPath+"/"+url.PathEscape(alertToken), nil, nil, nil))
+}
+
+func (c *RemindersClient) send(method, path string, reminder *Reminder) (*Reminder, error) {
+	body := *reminder
+	if body.RequestTime == "" {
+		body.RequestTime = time.Now().UTC().Format(reminderTimeLayout)
+	}
+
+	created := &Reminder{}
+	if err := c.api.Do(method, path, nil, &body, created); err != nil {
+		return nil, reminderError(err)
+	}
+
+	return created, nil
+}
+
+// reminderError maps the errors of the Reminders API to ErrMaxRemindersExceeded, ErrReminderNotFound,
+// ErrRemindersNotSupported and ErrPermissionMissing. Other errors are returned as they are.
+func reminderError(err error) error {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case apiErr.Code == "MAX_REMINDERS_EXCEEDED":
+		return ErrMaxRemindersExceeded
+	case apiErr.Code == "DEVICE_NOT_SUPPORTED":
+		return ErrRemindersNotSupported
+	case apiErr.Code == "ALERT_NOT_FOUND" || apiErr.StatusCode == http.StatusNotFound:
+		return ErrReminderNotFound
+	}
+
+	return permissionError(err)
+}
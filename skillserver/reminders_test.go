@@ -0,0 +1,130 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestReminderLifecycle(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	client := server.EchoRequest().Reminders()
+
+	created, err := client.Create(alexa.NewReminder(alexa.RelativeTrigger(time.Hour)).AddContent("en-US", "Walk the dog"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.AlertToken == "" || created.Status != "ON" {
+		t.Fatalf("created reminder = %+v", created)
+	}
+
+	updated, err := client.Update(created.AlertToken, alexa.NewReminder(alexa.RelativeTrigger(2*time.Hour)).AddContent("en-US", "Feed the dog"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.AlertToken != created.AlertToken || updated.AlertInfo.SpokenInfo.Content[0].Text != "Feed the dog" {
+		t.Fatalf("updated reminder = %+v", updated)
+	}
+
+	reminders, err := client.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reminders) != 1 || reminders[0].Trigger.OffsetInSeconds != 7200 {
+		t.Fatalf("listed reminders = %+v", reminders)
+	}
+
+	if err := client.Delete(created.AlertToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Get(created.AlertToken); err != alexa.ErrReminderNotFound {
+		t.Fatalf("Get after Delete returned %v, want ErrReminderNotFound", err)
+	}
+
+	if reminders, err := client.List(); err != nil || len(reminders) != 0 {
+		t.Fatalf("List after Delete = %v, %v", reminders, err)
+	}
+}
+
+func TestReminderSlotTrigger(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	req := server.EchoRequest()
+	req.Request.Intent.Slots = map[string]alexa.EchoSlot{
+		"day":  {Name: "day", Value: "2026-12-24"},
+		"time": {Name: "time", Value: "17:30"},
+	}
+
+	slots := req.AllSlots()
+
+	trigger, err := alexa.SlotTrigger(slots["day"], slots["time"], "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := req.Reminders().Create(alexa.NewReminder(trigger).AddContent("en-US", "Wrap the presents")); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := server.Reminders()
+	if len(stored) != 1 {
+		t.Fatalf("%d reminders stored, want 1", len(stored))
+	}
+
+	got := stored[0].Trigger
+	if got.Type != alexa.TriggerAbsolute || got.ScheduledTime != "2026-12-24T17:30:00.000" || got.TimeZoneID != "America/New_York" {
+		t.Fatalf("stored trigger = %+v", got)
+	}
+
+	evening, err := alexa.SlotTrigger(alexa.EchoSlot{Value: "2026-12-24"}, alexa.EchoSlot{Value: "EV"}, "")
+	if err != nil || evening.ScheduledTime != "2026-12-24T19:00:00.000" {
+		t.Fatalf("SlotTrigger for EV = %+v, %v", evening, err)
+	}
+
+	if _, err := alexa.SlotTrigger(alexa.EchoSlot{Value: "2026-W52"}, alexa.EchoSlot{Value: "17:30"}, ""); err == nil {
+		t.Fatal("SlotTrigger accepted a date slot naming a week")
+	}
+}
+
+func TestReminderMaxExceeded(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetMaxReminders(1)
+	client := server.EchoRequest().Reminders()
+
+	reminder := alexa.NewReminder(alexa.RelativeTrigger(time.Minute)).AddContent("en-US", "Stretch")
+	if _, err := client.Create(reminder); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Create(reminder); err != alexa.ErrMaxRemindersExceeded {
+		t.Fatalf("Create over the limit returned %v, want ErrMaxRemindersExceeded", err)
+	}
+}
+
+func TestReminderPermissionMissing(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetPermission(alexa.RemindersScope, false)
+	client := server.EchoRequest().Reminders()
+
+	reminder := alexa.NewReminder(alexa.RelativeTrigger(time.Minute)).AddContent("en-US", "Stretch")
+	if _, err := client.Create(reminder); err != alexa.ErrPermissionMissing {
+		t.Fatalf("Create without permission returned %v, want ErrPermissionMissing", err)
+	}
+
+	if _, err := client.List(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("List without permission returned %v, want ErrPermissionMissing", err)
+	}
+}
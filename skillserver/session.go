@@ -0,0 +1,197 @@
+package skillserver
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultSessionTTL is how long data in a SessionStore is kept when the EchoApplication does not set
+// a SessionTTL. Sessions that end normally are cleaned up before that.
+const DefaultSessionTTL = time.Hour
+
+// SessionStore keeps server side data for a session that is too large or too sensitive to be sent
+// back and forth as session attributes. Data saved with a TTL should no longer be returned once the
+// TTL has passed.
+type SessionStore interface {
+	// Load returns the data stored for the session, or an empty map if there is none.
+	Load(sessionID string) (map[string]interface{}, error)
+
+	// Save replaces the data stored for the session and resets its expiration.
+	Save(sessionID string, data map[string]interface{}, ttl time.Duration) error
+
+	// Delete removes the data stored for the session.
+	Delete(sessionID string) error
+}
+
+type sessionDataManager struct {
+	store     SessionStore
+	sessionID string
+	ttl       time.Duration
+	loaded    bool
+	data      map[string]interface{}
+}
+
+func newSessionDataManager(app EchoApplication, r *EchoRequest) *sessionDataManager {
+	ttl := app.SessionTTL
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	return &sessionDataManager{store: app.SessionStore, sessionID: r.GetSessionID(), ttl: ttl}
+}
+
+func (m *sessionDataManager) load() error {
+	if m.loaded {
+		return nil
+	}
+
+	data, err := m.store.Load(m.sessionID)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	m.data = data
+	m.loaded = true
+
+	return nil
+}
+
+// save stores the data of the session again, which also resets its expiration. Data no handler asked
+// for is loaded first so that it does not expire while the session is still going on.
+func (m *sessionDataManager) save() error {
+	if !m.loaded {
+		if err := m.load(); err != nil {
+			return err
+		}
+
+		if len(m.data) == 0 {
+			return nil
+		}
+	}
+
+	return m.store.Save(m.sessionID, m.data, m.ttl)
+}
+
+// SessionData returns the server side data kept for the session of the request, loading it from the
+// application's SessionStore the first time it is called. Changes to the returned map are saved once the
+// handler returns.
+func (r *EchoRequest) SessionData() (map[string]interface{}, error) {
+	if r.sessionData == nil {
+		return nil, errors.New("no session store configured for this application")
+	}
+
+	if r.GetSessionID() == "" {
+		return nil, errors.New("request is not part of a session")
+	}
+
+	if err := r.sessionData.load(); err != nil {
+		return nil, err
+	}
+
+	return r.sessionData.data, nil
+}
+
+// sessionEnded reports whether the session of the request is over after this request, either
+// because the Alexa service sent a SessionEndedRequest (USER_INITIATED, EXCEEDED_MAX_REPROMPTS or
+// ERROR) or because the response closes it. Responses end the session by default, so handlers that
+// keep data for the next request must leave the session open with `EndSession(false)` or a dialog
+// directive.
+func sessionEnded(r *EchoRequest, resp *EchoResponse) bool {
+	if r.GetRequestType() == "SessionEndedRequest" {
+		return true
+	}
+
+	return resp.Response.ShouldEndSession
+}
+
+func saveSessionData(r *EchoRequest, resp *EchoResponse) {
+	if r.sessionData == nil || r.GetSessionID() == "" {
+		return
+	}
+
+	var err error
+	if sessionEnded(r, resp) {
+		err = r.sessionData.store.Delete(r.GetSessionID())
+	} else {
+		err = r.sessionData.save()
+	}
+
+	if err != nil {
+		log.Println("Could not update session store: " + err.Error())
+	}
+}
+
+func logSessionEnded(r *EchoRequest) {
+	if reqErr := r.GetSessionEndedError(); reqErr != nil {
+		log.Println("Session " + r.GetSessionID() + " ended with " + reqErr.Type + ": " + reqErr.Message)
+	}
+}
+
+// MemorySessionStore is a SessionStore that keeps session data in memory. Expired sessions are removed
+// as the store is used.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]memorySession
+}
+
+type memorySession struct {
+	data    map[string]interface{}
+	expires time.Time
+}
+
+// NewMemorySessionStore returns an empty MemorySessionStore.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: map[string]memorySession{}}
+}
+
+// Load implements SessionStore.
+func (s *MemorySessionStore) Load(sessionID string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || time.Now().After(session.expires) {
+		delete(s.sessions, sessionID)
+		return map[string]interface{}{}, nil
+	}
+
+	return copyAttributes(session.data)
+}
+
+// Save implements SessionStore.
+func (s *MemorySessionStore) Save(sessionID string, data map[string]interface{}, ttl time.Duration) error {
+	copied, err := copyAttributes(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range s.sessions {
+		if now.After(session.expires) {
+			delete(s.sessions, id)
+		}
+	}
+
+	s.sessions[sessionID] = memorySession{data: copied, expires: now.Add(ttl)}
+
+	return nil
+}
+
+// Delete implements SessionStore.
+func (s *MemorySessionStore) Delete(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package skillserver_test
+
+import (
+	"testing"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+func TestSessionDataRemovedWhenSessionEnds(t *testing.T) {
+	store := alexa.NewMemorySessionStore()
+	store.Save("amzn1.echo-api.session.test", map[string]interface{}{"cart": "latte"}, alexa.DefaultSessionTTL)
+
+	keepOpen := true
+	app := alexa.EchoApplication{
+		SessionStore: store,
+		OnIntent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			resp.OutputSpeech("Anything else?")
+			if keepOpen {
+				resp.EndSession(false)
+			}
+		},
+	}
+
+	req := &alexa.EchoRequest{}
+	req.Session.SessionID = "amzn1.echo-api.session.test"
+	req.Request.Type = "IntentRequest"
+
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if data, _ := store.Load("amzn1.echo-api.session.test"); data["cart"] != "latte" {
+		t.Fatalf("data = %v after a response that kept the session open", data)
+	}
+
+	keepOpen = false
+	if w := alexa.ServeEcho(app, req); w.Code != 200 {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if data, _ := store.Load("amzn1.echo-api.session.test"); len(data) != 0 {
+		t.Fatalf("data = %v after a response that ended the session", data)
+	}
+}
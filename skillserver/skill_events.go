@@ -0,0 +1,86 @@
+package skillserver
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Details about skill events can be found on this page:
+// https://developer.amazon.com/docs/smapi/skill-events-in-alexa-skills.html
+
+const (
+	// SkillEnabledEvent is sent when the user enables the skill.
+	SkillEnabledEvent = "AlexaSkillEvent.SkillEnabled"
+	// SkillDisabledEvent is sent when the user disables the skill. The user ID of later requests will differ.
+	SkillDisabledEvent = "AlexaSkillEvent.SkillDisabled"
+	// SkillAccountLinkedEvent is sent when the user links their account, with the access token in the body.
+	SkillAccountLinkedEvent = "AlexaSkillEvent.SkillAccountLinked"
+	// SkillPermissionAcceptedEvent is sent when the user grants the skill permissions for the first time.
+	SkillPermissionAcceptedEvent = "AlexaSkillEvent.SkillPermissionAccepted"
+	// SkillPermissionChangedEvent is sent when the user grants or revokes permissions later on.
+	SkillPermissionChangedEvent = "AlexaSkillEvent.SkillPermissionChanged"
+)
+
+// EchoPermission is a permission scope granted to the skill.
+type EchoPermission struct {
+	Scope string `json:"scope"`
+}
+
+// EchoSkillEventBody is the body of an AlexaSkillEvent request. AcceptedPermissions and AcceptedPersonPermissions
+// are set for permission events and list every scope granted after the change. AccessToken is set for
+// SkillAccountLinked, and UserInformationPersistenceStatus for SkillDisabled, where NOT_PERSISTED means data
+// kept for the user should be deleted.
+type EchoSkillEventBody struct {
+	AcceptedPermissions              []EchoPermission `json:"acceptedPermissions,omitempty"`
+	AcceptedPersonPermissions        []EchoPermission `json:"acceptedPersonPermissions,omitempty"`
+	AccessToken                      string           `json:"accessToken,omitempty"`
+	UserInformationPersistenceStatus string           `json:"userInformationPersistenceStatus,omitempty"`
+}
+
+// GetSkillEvent is a convenience method for getting the body of an AlexaSkillEvent request. Events without
+// a body, such as SkillEnabled, return an empty body.
+func (r *EchoRequest) GetSkillEvent() (*EchoSkillEventBody, error) {
+	if !strings.HasPrefix(r.GetRequestType(), "AlexaSkillEvent.") {
+		return nil, errors.New("request is not an AlexaSkillEvent")
+	}
+
+	body := &EchoSkillEventBody{}
+	if len(r.Request.Body) == 0 {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(r.Request.Body, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// GetEventCreationTime is a convenience method for getting the time the user caused a skill event, which
+// can be well before the request was sent.
+func (r *EchoRequest) GetEventCreationTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, r.Request.EventCreationTime)
+}
+
+// Scopes returns the permission scopes granted by the user.
+func (b *EchoSkillEventBody) Scopes() []string {
+	scopes := make([]string, len(b.AcceptedPermissions))
+	for i, permission := range b.AcceptedPermissions {
+		scopes[i] = permission.Scope
+	}
+
+	return scopes
+}
+
+// HasScope reports whether the user granted the permission scope.
+func (b *EchoSkillEventBody) HasScope(scope string) bool {
+	for _, permission := range b.AcceptedPermissions {
+		if permission.Scope == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package skillserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+// postEcho sends the request through the router of the application as the Alexa service would.
+func postEcho(t *testing.T, app alexa.EchoApplication, req *alexa.EchoRequest) *httptest.ResponseRecorder {
+	alexa.SetVerifyAWSCerts(false)
+	defer alexa.SetVerifyAWSCerts(true)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	alexa.Router(map[string]interface{}{"/echo/test": app}).ServeHTTP(w, httptest.NewRequest("POST", "/echo/test", bytes.NewReader(body)))
+
+	return w
+}
+
+func TestSkillEventsAcceptedForAnHour(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	var scopes []string
+	app := alexa.EchoApplication{
+		AppID: server.EchoRequest().Context.System.Application.ApplicationID,
+		OnSkillEvent: func(req *alexa.EchoRequest, resp *alexa.EchoResponse) {
+			body, err := req.GetSkillEvent()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scopes = body.Scopes()
+		},
+	}
+
+	late := time.Now().UTC().Add(-30 * time.Minute).Format(time.RFC3339)
+
+	event := server.PermissionEvent(alexa.SkillPermissionAcceptedEvent, alexa.RemindersScope)
+	event.Request.Timestamp = late
+	if w := postEcho(t, app, event); w.Code != 200 {
+		t.Fatalf("status = %d for a skill event sent 30 minutes late: %s", w.Code, w.Body.String())
+	}
+
+	if len(scopes) != 1 || scopes[0] != alexa.RemindersScope {
+		t.Fatalf("scopes = %v, want the reminders scope", scopes)
+	}
+
+	enabled := server.SkillEvent(alexa.SkillEnabledEvent, alexa.EchoSkillEventBody{})
+	enabled.Request.Timestamp = time.Now().UTC().Add(-2 * time.Hour).Format(time.RFC3339)
+	if w := postEcho(t, app, enabled); w.Code != 400 {
+		t.Fatalf("status = %d for a skill event sent two hours late, want 400", w.Code)
+	}
+
+	intent := server.EchoRequest()
+	intent.Request.Timestamp = late
+	if w := postEcho(t, app, intent); w.Code != 400 {
+		t.Fatalf("status = %d for an IntentRequest sent 30 minutes late, want 400", w.Code)
+	}
+}
@@ -35,6 +35,75 @@ type EchoApplication struct {
 	OnIntent           func(*EchoRequest, *EchoResponse)
 	OnSessionEnded     func(*EchoRequest, *EchoResponse)
 	OnAudioPlayerState func(*EchoRequest, *EchoResponse)
+
+	// OnDialogAPIInvoked is called for Dialog.API.Invoked requests sent by Alexa Conversations. The result
+	// of the API should be set with `EchoResponse.APIResponse`.
+	OnDialogAPIInvoked func(*EchoRequest, *EchoResponse)
+
+	// OnAPLUserEvent is called for Alexa.Presentation.APL.UserEvent requests sent by the SendEvent command
+	// of a rendered APL document.
+	OnAPLUserEvent func(*EchoRequest, *EchoResponse)
+
+	// OnDisplayElementSelected is called for Display.ElementSelected requests sent when the user selects
+	// an item of a display template. The token of the item is available from `EchoRequest.Request.Token`.
+	OnDisplayElementSelected func(*EchoRequest, *EchoResponse)
+
+	// OnConnectionsResponse is called for Connections.Response requests carrying the result of a purchase
+	// flow started with `BuyProduct`, `UpsellProduct` or `CancelProduct`. Session attributes of the response
+	// that started the flow are restored into the request.
+	OnConnectionsResponse func(*EchoRequest, *EchoResponse)
+
+	// OnListEvent is called for the AlexaHouseholdListEvent requests sent outside of a session when the user
+	// changes a list, such as `ListItemsCreatedEvent`. `EchoRequest.GetListEvent` returns what changed.
+	OnListEvent func(*EchoRequest, *EchoResponse)
+
+	// OnMessageReceived is called for the Messaging.MessageReceived requests sent outside of a session for
+	// messages posted with a `MessagingClient`. `EchoRequest.GetMessage` decodes the message.
+	OnMessageReceived func(*EchoRequest, *EchoResponse)
+
+	// OnSkillEvent is called for the AlexaSkillEvent requests sent when the user enables or disables the skill,
+	// links their account or changes its permissions, such as `SkillEnabledEvent`. `EchoRequest.GetSkillEvent`
+	// returns the details of the event.
+	OnSkillEvent func(*EchoRequest, *EchoResponse)
+
+	// OnSessionStarted is called before any other handler for the first request of a new session.
+	OnSessionStarted func(*EchoRequest, *EchoResponse)
+
+	// SessionStore is the optional server side storage used for `EchoRequest.SessionData`. Data is kept
+	// for SessionTTL (`DefaultSessionTTL` if not set) and removed as soon as the session ends.
+	SessionStore SessionStore
+	SessionTTL   time.Duration
+
+	// Persistence is the optional storage used for `EchoRequest.PersistentAttributes`. Attributes are
+	// loaded when a handler first asks for them and saved after the handler returns if they changed.
+	// PersistenceKey picks the key they are stored under and defaults to `UserIDKey`.
+	Persistence    PersistenceAdapter
+	PersistenceKey PersistenceKeyFunc
+
+	// OnPersistenceError is called when the changed persistent attributes can't be saved after the handler
+	// returned, e.g. with ErrPersistenceConflict when another request of the user saved first. The response
+	// is still sent if it returns nil, so it can tell the user the change was not kept. Without it, or when
+	// it returns an error, the request fails with a 500.
+	OnPersistenceError func(*EchoRequest, *EchoResponse, error) error
+
+	// Dialogs optionally runs the multi-turn dialog of the intents registered with it. Intents it handles
+	// are not passed to OnIntent.
+	Dialogs *DialogManager
+
+	// AttributeCodec optionally encrypts and authenticates selected session attributes. Protected attributes
+	// are opened before any handler runs, and requests where they fail authentication are rejected.
+	AttributeCodec *AttributeCodec
+
+	// ProgressiveResponseAfter enables an automatic progressive response for LaunchRequest and IntentRequest
+	// handlers that take longer than this to return. ProgressiveResponseSpeech is spoken, or
+	// `DefaultProgressiveSpeech` when it is empty. A progressive response that is still being sent when the
+	// handler returns delays the response by at most two seconds.
+	ProgressiveResponseAfter  time.Duration
+	ProgressiveResponseSpeech string
+
+	// CarrySessionAttributes will copy the session attributes of every request into its response
+	// before the handlers are called, so attributes survive a turn unless a handler changes them.
+	CarrySessionAttributes bool
 }
 
 // StdApplication is a type of application that allows the user to accept and manually process
@@ -156,34 +225,7 @@ func initialize(apps map[string]interface{}, router *mux.Router) {
 	for uri, meta := range applications {
 		switch app := meta.(type) {
 		case EchoApplication:
-			handlerFunc := func(w http.ResponseWriter, r *http.Request) {
-				echoReq := GetEchoRequest(r)
-				echoResp := NewEchoResponse()
-
-				if echoReq.GetRequestType() == "LaunchRequest" {
-					if app.OnLaunch != nil {
-						app.OnLaunch(echoReq, echoResp)
-					}
-				} else if echoReq.GetRequestType() == "IntentRequest" {
-					if app.OnIntent != nil {
-						app.OnIntent(echoReq, echoResp)
-					}
-				} else if echoReq.GetRequestType() == "SessionEndedRequest" {
-					if app.OnSessionEnded != nil {
-						app.OnSessionEnded(echoReq, echoResp)
-					}
-				} else if strings.HasPrefix(echoReq.GetRequestType(), "AudioPlayer.") {
-					if app.OnAudioPlayerState != nil {
-						app.OnAudioPlayerState(echoReq, echoResp)
-					}
-				} else {
-					http.Error(w, "Invalid request.", http.StatusBadRequest)
-				}
-
-				json, _ := echoResp.String()
-				w.Header().Set("Content-Type", "application/json;charset=UTF-8")
-				w.Write(json)
-			}
+			handlerFunc := app.serveEcho
 
 			if app.Handler != nil {
 				handlerFunc = app.Handler
@@ -209,6 +251,141 @@ func initialize(apps map[string]interface{}, router *mux.Router) {
 	}
 }
 
+// serveEcho is the default HTTP handler of an EchoApplication. It calls the hook matching the
+// request type and writes the resulting EchoResponse.
+func (app EchoApplication) serveEcho(w http.ResponseWriter, r *http.Request) {
+	echoReq := GetEchoRequest(r)
+	echoResp := NewEchoResponse()
+
+	if app.AttributeCodec != nil {
+		if err := app.AttributeCodec.Open(echoReq.GetSessionID(), echoReq.Session.Attributes); err != nil {
+			HTTPError(w, "Session attributes rejected: "+err.Error(), "Bad Request", 400)
+			return
+		}
+	}
+
+	if echoReq.GetRequestType() == "Connections.Response" {
+		if err := restoreSessionAttributes(echoReq, app.AttributeCodec); err != nil {
+			HTTPError(w, "Stashed session attributes rejected: "+err.Error(), "Bad Request", 400)
+			return
+		}
+	}
+
+	if app.CarrySessionAttributes {
+		echoResp.CarrySessionAttributes(echoReq)
+	}
+
+	if app.Persistence != nil {
+		echoReq.persistence = newPersistenceManager(app, echoReq)
+	}
+
+	if app.SessionStore != nil {
+		echoReq.sessionData = newSessionDataManager(app, echoReq)
+	}
+
+	stopProgressiveResponse := startProgressiveResponse(app, echoReq)
+
+	if echoReq.Session.New && app.OnSessionStarted != nil {
+		app.OnSessionStarted(echoReq, echoResp)
+	}
+
+	if echoReq.GetRequestType() == "LaunchRequest" {
+		if app.OnLaunch != nil {
+			app.OnLaunch(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "IntentRequest" {
+		handled := app.Dialogs != nil && app.Dialogs.Handle(echoReq, echoResp)
+		if !handled && app.OnIntent != nil {
+			app.OnIntent(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "SessionEndedRequest" {
+		logSessionEnded(echoReq)
+
+		if app.OnSessionEnded != nil {
+			app.OnSessionEnded(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "Dialog.API.Invoked" {
+		if app.OnDialogAPIInvoked != nil {
+			app.OnDialogAPIInvoked(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "Alexa.Presentation.APL.UserEvent" {
+		if app.OnAPLUserEvent != nil {
+			app.OnAPLUserEvent(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "Display.ElementSelected" {
+		if app.OnDisplayElementSelected != nil {
+			app.OnDisplayElementSelected(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "Connections.Response" {
+		if app.OnConnectionsResponse != nil {
+			app.OnConnectionsResponse(echoReq, echoResp)
+		}
+	} else if strings.HasPrefix(echoReq.GetRequestType(), "AlexaHouseholdListEvent.") {
+		if app.OnListEvent != nil {
+			app.OnListEvent(echoReq, echoResp)
+		}
+	} else if strings.HasPrefix(echoReq.GetRequestType(), "AlexaSkillEvent.") {
+		if app.OnSkillEvent != nil {
+			app.OnSkillEvent(echoReq, echoResp)
+		}
+	} else if echoReq.GetRequestType() == "Messaging.MessageReceived" {
+		if app.OnMessageReceived != nil {
+			app.OnMessageReceived(echoReq, echoResp)
+		}
+	} else if strings.HasPrefix(echoReq.GetRequestType(), "AudioPlayer.") {
+		if app.OnAudioPlayerState != nil {
+			app.OnAudioPlayerState(echoReq, echoResp)
+		}
+	} else {
+		stopProgressiveResponse()
+		http.Error(w, "Invalid request.", http.StatusBadRequest)
+		return
+	}
+
+	stopProgressiveResponse()
+
+	echoResp.RemoveUnsupportedDirectives(echoReq)
+
+	if err := echoResp.Validate(); err != nil {
+		HTTPError(w, "Invalid response: "+err.Error(), "Internal Error", 500)
+		return
+	}
+
+	if err := savePersistentAttributes(echoReq); err != nil {
+		if app.OnPersistenceError != nil {
+			err = app.OnPersistenceError(echoReq, echoResp, err)
+		}
+
+		if err != nil {
+			HTTPError(w, "Could not save persistent attributes: "+err.Error(), "Internal Error", 500)
+			return
+		}
+	}
+
+	saveSessionData(echoReq, echoResp)
+
+	if err := stashSessionAttributes(echoReq, echoResp, app.AttributeCodec); err != nil {
+		HTTPError(w, "Could not stash session attributes: "+err.Error(), "Internal Error", 500)
+		return
+	}
+
+	if app.AttributeCodec != nil {
+		if err := app.AttributeCodec.Seal(echoReq.GetSessionID(), echoResp.SessionAttributes); err != nil {
+			HTTPError(w, "Could not seal session attributes: "+err.Error(), "Internal Error", 500)
+			return
+		}
+	}
+
+	json, err := echoResp.String()
+	if err != nil {
+		HTTPError(w, "Could not encode response: "+err.Error(), "Internal Error", 500)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Write(json)
+}
+
 // GetEchoRequest is a convenience method for retrieving and casting an `EchoRequest` out of a
 // standard `http.Request`.
 func GetEchoRequest(r *http.Request) *EchoRequest {
@@ -236,7 +413,7 @@ func verifyJSON(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 
 	// Check the timestamp
 	if !echoReq.VerifyTimestamp() && r.URL.Query().Get("_dev") == "" {
-		HTTPError(w, "Request too old to continue (>150s).", "Bad Request", 400)
+		HTTPError(w, "Request too old to continue (>"+echoReq.timestampTolerance().String()+").", "Bad Request", 400)
 		return
 	}
 
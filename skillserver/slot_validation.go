@@ -0,0 +1,153 @@
+package skillserver
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxSlotAttempts is the number of invalid values accepted for a slot before the dialog
+// falls back, used when neither the DialogSlot nor the DialogManager set a limit.
+const DefaultMaxSlotAttempts = 3
+
+// slotAttemptsKey is the session attribute the DialogManager counts failed slot values in.
+const slotAttemptsKey = "_slotAttempts"
+
+// SlotValidator checks the value of a slot. The message of a returned error is spoken to the user
+// before the slot is elicited again, so it should explain what was wrong.
+type SlotValidator func(*EchoRequest, EchoSlot) error
+
+// OneOf accepts slot values matching one of the provided values, ignoring case. Values resolved through
+// entity resolution are accepted as well.
+func OneOf(message string, values ...string) SlotValidator {
+	allowed := make(map[string]bool, len(values))
+	for _, v := range values {
+		allowed[strings.ToLower(v)] = true
+	}
+
+	return func(req *EchoRequest, slot EchoSlot) error {
+		if allowed[strings.ToLower(slot.Value)] {
+			return nil
+		}
+
+		for _, authority := range slot.Resolutions.ResolutionsPerAuthority {
+			for _, value := range authority.Values {
+				for _, resolved := range value {
+					if allowed[strings.ToLower(resolved.Name)] {
+						return nil
+					}
+				}
+			}
+		}
+
+		return errors.New(message)
+	}
+}
+
+// InRange accepts numeric slot values between min and max, inclusive.
+func InRange(message string, min, max float64) SlotValidator {
+	return func(req *EchoRequest, slot EchoSlot) error {
+		n, err := strconv.ParseFloat(slot.Value, 64)
+		if err != nil || n < min || n > max {
+			return errors.New(message)
+		}
+
+		return nil
+	}
+}
+
+// DateBetween accepts AMAZON.DATE slot values naming a single day between from and to, inclusive.
+// Either bound can be the zero time to leave that side open. Values such as weeks or months are rejected.
+func DateBetween(message string, from, to time.Time) SlotValidator {
+	return func(req *EchoRequest, slot EchoSlot) error {
+		day, err := time.Parse("2006-01-02", slot.Value)
+		if err != nil {
+			return errors.New(message)
+		}
+
+		if !from.IsZero() && day.Before(truncateDay(from)) {
+			return errors.New(message)
+		}
+
+		if !to.IsZero() && day.After(truncateDay(to)) {
+			return errors.New(message)
+		}
+
+		return nil
+	}
+}
+
+// Matches accepts slot values matching the regular expression.
+func Matches(message string, pattern *regexp.Regexp) SlotValidator {
+	return func(req *EchoRequest, slot EchoSlot) error {
+		if !pattern.MatchString(slot.Value) {
+			return errors.New(message)
+		}
+
+		return nil
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// validateSlot runs the validators of a DialogSlot in order and returns the first error.
+func validateSlot(req *EchoRequest, slot DialogSlot, value EchoSlot) error {
+	if slot.Validate != nil {
+		if err := slot.Validate(req, value); err != nil {
+			return err
+		}
+	}
+
+	for _, validator := range slot.Validators {
+		if err := validator(req, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// slotAttempts returns the number of invalid values given so far for the slot of the intent.
+func slotAttempts(req *EchoRequest, intent, slot string) int {
+	attempts, _ := req.Session.Attributes[slotAttemptsKey].(map[string]interface{})
+
+	switch n := attempts[intent+"."+slot].(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+
+	return 0
+}
+
+// setSlotAttempts records the number of invalid values for the slot in the response's session attributes,
+// removing the record when it drops to zero.
+func setSlotAttempts(req *EchoRequest, resp *EchoResponse, intent, slot string, n int) {
+	attempts := map[string]interface{}{}
+	if current, ok := req.Session.Attributes[slotAttemptsKey].(map[string]interface{}); ok {
+		for k, v := range current {
+			attempts[k] = v
+		}
+	}
+
+	if n > 0 {
+		attempts[intent+"."+slot] = n
+	} else {
+		delete(attempts, intent+"."+slot)
+	}
+
+	if resp.SessionAttributes == nil {
+		resp.SessionAttributes = make(map[string]interface{})
+	}
+
+	if len(attempts) > 0 {
+		resp.SessionAttributes[slotAttemptsKey] = attempts
+	} else {
+		delete(resp.SessionAttributes, slotAttemptsKey)
+	}
+}
@@ -0,0 +1,51 @@
+package skillserver_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+)
+
+func TestSlotValidators(t *testing.T) {
+	var resolved alexa.EchoSlot
+	json.Unmarshal([]byte(`{"name":"Size","value":"big","resolutions":{"resolutionsPerAuthority":[
+		{"authority":"sizes","status":{"code":"ER_SUCCESS_MATCH"},"values":[{"value":{"name":"Large","id":"L"}}]}]}}`), &resolved)
+
+	from := time.Date(2026, 12, 1, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		validator alexa.SlotValidator
+		value     alexa.EchoSlot
+		valid     bool
+	}{
+		{"one of", alexa.OneOf("no", "small", "large"), alexa.EchoSlot{Value: "Small"}, true},
+		{"one of resolved", alexa.OneOf("no", "small", "large"), resolved, true},
+		{"one of unknown", alexa.OneOf("no", "small", "large"), alexa.EchoSlot{Value: "huge"}, false},
+		{"in range", alexa.InRange("no", 1, 10), alexa.EchoSlot{Value: "10"}, true},
+		{"out of range", alexa.InRange("no", 1, 10), alexa.EchoSlot{Value: "11"}, false},
+		{"not a number", alexa.InRange("no", 1, 10), alexa.EchoSlot{Value: "?"}, false},
+		{"first day", alexa.DateBetween("no", from, to), alexa.EchoSlot{Value: "2026-12-01"}, true},
+		{"day before", alexa.DateBetween("no", from, to), alexa.EchoSlot{Value: "2026-11-30"}, false},
+		{"day after", alexa.DateBetween("no", from, to), alexa.EchoSlot{Value: "2027-01-01"}, false},
+		{"open end", alexa.DateBetween("no", from, time.Time{}), alexa.EchoSlot{Value: "2030-01-01"}, true},
+		{"week", alexa.DateBetween("no", from, to), alexa.EchoSlot{Value: "2026-W50"}, false},
+		{"matches", alexa.Matches("no", regexp.MustCompile(`^\d{5}$`)), alexa.EchoSlot{Value: "90210"}, true},
+		{"no match", alexa.Matches("no", regexp.MustCompile(`^\d{5}$`)), alexa.EchoSlot{Value: "9021"}, false},
+	}
+
+	for _, test := range tests {
+		err := test.validator(&alexa.EchoRequest{}, test.value)
+		if test.valid && err != nil {
+			t.Errorf("%s: rejected %q: %v", test.name, test.value.Value, err)
+		}
+
+		if !test.valid && (err == nil || err.Error() != "no") {
+			t.Errorf("%s: returned %v for %q, want the validator message", test.name, err, test.value.Value)
+		}
+	}
+}
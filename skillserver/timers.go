@@ -0,0 +1,239 @@
+package skillserver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Details about the Timers API can be found on this page:
+// https://developer.amazon.com/docs/alexa/smapi/alexa-timers-api-reference.html
+
+// TimersScope is the permission needed to create and manage timers for the user.
+const TimersScope = "alexa::alerts:timers:skill:readwrite"
+
+const timersPath = "/v1/alerts/timers"
+
+var (
+	// ErrMaxTimersExceeded is returned when the user already has the maximum number of timers running.
+	ErrMaxTimersExceeded = errors.New("the user has reached the maximum number of timers")
+
+	// ErrTimerNotFound is returned when no timer exists for the ID.
+	ErrTimerNotFound = errors.New("timer not found")
+)
+
+// TimerOperationType selects what happens when a timer goes off.
+type TimerOperationType string
+
+const (
+	// TimerNotifyOnly timers play the timer sound.
+	TimerNotifyOnly TimerOperationType = "NOTIFY_ONLY"
+
+	// TimerAnnounce timers speak a text when they go off.
+	TimerAnnounce TimerOperationType = "ANNOUNCE"
+
+	// TimerLaunchTask timers ask the user to confirm and then launch a task of the skill, which arrives as a
+	// LaunchRequest carrying the task (see `EchoRequest.GetTask`).
+	TimerLaunchTask TimerOperationType = "LAUNCH_TASK"
+)
+
+// EchoTask is a task of the skill, started by a timer or another skill. Name is "<skill ID>.<task name>".
+type EchoTask struct {
+	Name    string                 `json:"name"`
+	Version string                 `json:"version"`
+	Input   map[string]interface{} `json:"input,omitempty"`
+}
+
+// Timer is a timer as it is sent to and returned by the Timers API. The ID, status and times are filled in
+// by the API. Durations are ISO-8601 durations; see ParseDuration.
+type Timer struct {
+	ID                      string                   `json:"id,omitempty"`
+	Status                  string                   `json:"status,omitempty"`
+	Duration                string                   `json:"duration"`
+	TimerLabel              string                   `json:"timerLabel,omitempty"`
+	TriggerTime             string                   `json:"triggerTime,omitempty"`
+	CreatedTime             string                   `json:"createdTime,omitempty"`
+	UpdatedTime             string                   `json:"updatedTime,omitempty"`
+	RemainingTimeWhenPaused string                   `json:"remainingTimeWhenPaused,omitempty"`
+	CreationBehavior        *TimerCreationBehavior   `json:"creationBehavior,omitempty"`
+	TriggeringBehavior      *TimerTriggeringBehavior `json:"triggeringBehavior,omitempty"`
+}
+
+// TimerCreationBehavior sets whether the timer is shown on devices with a screen. Visibility is VISIBLE or HIDDEN.
+type TimerCreationBehavior struct {
+	DisplayExperience TimerDisplayExperience `json:"displayExperience"`
+}
+
+// TimerDisplayExperience holds the visibility of a timer.
+type TimerDisplayExperience struct {
+	Visibility string `json:"visibility"`
+}
+
+// TimerTriggeringBehavior is what happens when the timer goes off.
+type TimerTriggeringBehavior struct {
+	Operation          TimerOperation          `json:"operation"`
+	NotificationConfig TimerNotificationConfig `json:"notificationConfig"`
+}
+
+// TimerOperation is the action of a timer. TextToAnnounce is used by ANNOUNCE timers, TextToConfirm and Task by
+// LAUNCH_TASK timers.
+type TimerOperation struct {
+	Type           TimerOperationType `json:"type"`
+	TextToAnnounce []TimerText        `json:"textToAnnounce,omitempty"`
+	TextToConfirm  []TimerText        `json:"textToConfirm,omitempty"`
+	Task           *EchoTask          `json:"task,omitempty"`
+}
+
+// TimerText is a text spoken by a timer in a single locale.
+type TimerText struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text"`
+}
+
+// TimerNotificationConfig sets whether the timer sound is played when the timer goes off.
+type TimerNotificationConfig struct {
+	PlayAudible bool `json:"playAudible"`
+}
+
+// NewTimer returns a visible timer for the duration that plays the timer sound when it goes off. The label is optional.
+func NewTimer(d time.Duration, label string) *Timer {
+	return &Timer{
+		Duration:         FormatDuration(d),
+		TimerLabel:       label,
+		CreationBehavior: &TimerCreationBehavior{DisplayExperience: TimerDisplayExperience{Visibility: "VISIBLE"}},
+		TriggeringBehavior: &TimerTriggeringBehavior{
+			Operation:          TimerOperation{Type: TimerNotifyOnly},
+			NotificationConfig: TimerNotificationConfig{PlayAudible: true},
+		},
+	}
+}
+
+// NewAnnounceTimer returns a timer that speaks the text in the locale when it goes off.
+func NewAnnounceTimer(d time.Duration, label, locale, text string) *Timer {
+	timer := NewTimer(d, label)
+	timer.TriggeringBehavior.Operation = TimerOperation{
+		Type:           TimerAnnounce,
+		TextToAnnounce: []TimerText{{Locale: locale, Text: text}},
+	}
+
+	return timer
+}
+
+// NewLaunchTaskTimer returns a timer that asks the user with the confirmation text whether to launch the task
+// when it goes off.
+func NewLaunchTaskTimer(d time.Duration, label, locale, confirmText string, task *EchoTask) *Timer {
+	timer := NewTimer(d, label)
+	timer.TriggeringBehavior.Operation = TimerOperation{
+		Type:          TimerLaunchTask,
+		TextToConfirm: []TimerText{{Locale: locale, Text: confirmText}},
+		Task:          task,
+	}
+
+	return timer
+}
+
+// Hide keeps the timer from being shown on devices with a screen.
+func (t *Timer) Hide() *Timer {
+	t.CreationBehavior.DisplayExperience.Visibility = "HIDDEN"
+	return t
+}
+
+// Silence keeps the timer sound from being played when the timer goes off.
+func (t *Timer) Silence() *Timer {
+	t.TriggeringBehavior.NotificationConfig.PlayAudible = false
+	return t
+}
+
+// GetTask is a convenience method for getting the task a LaunchRequest was started for, such as the task of
+// a LAUNCH_TASK timer. It returns nil for requests without a task.
+func (r *EchoRequest) GetTask() *EchoTask {
+	return r.Request.Task
+}
+
+// TimersClient creates and manages timers for the user. Every method returns ErrPermissionMissing if the user
+// has not granted the TimersScope permission.
+type TimersClient struct {
+	api *APIClient
+}
+
+// NewTimersClient returns a TimersClient using the API client.
+func NewTimersClient(api *APIClient) *TimersClient {
+	return &TimersClient{api: api}
+}
+
+// Timers returns a TimersClient for the user of the request.
+func (r *EchoRequest) Timers() *TimersClient {
+	return NewTimersClient(r.APIClient())
+}
+
+// Start creates and starts the timer and returns it with the ID and trigger time set by the API.
+func (c *TimersClient) Start(timer *Timer) (*Timer, error) {
+	started := &Timer{}
+	if err := c.api.Do("POST", timersPath, nil, timer, started); err != nil {
+		return nil, timerError(err)
+	}
+
+	return started, nil
+}
+
+// Get returns the timer with the ID.
+func (c *TimersClient) Get(id string) (*Timer, error) {
+	timer := &Timer{}
+	if err := c.api.Do("GET", timersPath+"/"+url.PathEscape(id), nil, nil, timer); err != nil {
+		return nil, timerError(err)
+	}
+
+	return timer, nil
+}
+
+// List returns all timers the skill set for the user.
+func (c *TimersClient) List() ([]Timer, error) {
+	var list struct {
+		Timers []Timer `json:"timers"`
+	}
+
+	if err := c.api.Do("GET", timersPath, nil, nil, &list); err != nil {
+		return nil, timerError(err)
+	}
+
+	return list.Timers, nil
+}
+
+// Pause pauses the running timer with the ID.
+func (c *TimersClient) Pause(id string) error {
+	return timerError(c.api.Do("POST", timersPath+"/"+url.PathEscape(id)+"/pause", nil, nil, nil))
+}
+
+// Resume resumes the paused timer with the ID.
+func (c *TimersClient) Resume(id string) error {
+	return timerError(c.api.Do("POST", timersPath+"/"+url.PathEscape(id)+"/resume", nil, nil, nil))
+}
+
+// Cancel cancels and deletes the timer with the ID.
+func (c *TimersClient) Cancel(id string) error {
+	return timerError(c.api.Do("DELETE", timersPath+"/"+url.PathEscape(id), nil, nil, nil))
+}
+
+// CancelAll cancels and deletes all timers the skill set for the user.
+func (c *TimersClient) CancelAll() error {
+	return timerError(c.api.Do("DELETE", timersPath, nil, nil, nil))
+}
+
+// timerError maps the errors of the Timers API to ErrMaxTimersExceeded, ErrTimerNotFound and
+// ErrPermissionMissing. Other errors are returned as they are.
+func timerError(err error) error {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case apiErr.Code == "MAX_TIMERS_EXCEEDED":
+		return ErrMaxTimersExceeded
+	case apiErr.Code == "TIMER_NOT_FOUND" || apiErr.StatusCode == http.StatusNotFound:
+		return ErrTimerNotFound
+	}
+
+	return permissionError(err)
+}
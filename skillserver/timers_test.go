@@ -0,0 +1,111 @@
+package skillserver_test
+
+import (
+	"testing"
+	"time"
+
+	alexa "github.com/mikeflynn/go-alexa/skillserver"
+	"github.com/mikeflynn/go-alexa/skillserver/alexatest"
+)
+
+func TestTimerLifecycle(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	req := server.EchoRequest()
+	req.Request.Intent.Slots = map[string]alexa.EchoSlot{"duration": {Name: "duration", Value: "PT5M"}}
+
+	d, err := req.GetSlotDuration("duration")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := req.Timers()
+
+	task := &alexa.EchoTask{Name: "amzn1.ask.skill.test.PlankTimer", Version: "1"}
+	started, err := client.Start(alexa.NewLaunchTaskTimer(d, "plank", "en-US", "Keep going?", task).Hide())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if started.ID == "" || started.Status != "ON" || started.Duration != "PT5M" {
+		t.Fatalf("started timer = %+v", started)
+	}
+
+	if started.TriggeringBehavior.Operation.Task.Name != task.Name || started.CreationBehavior.DisplayExperience.Visibility != "HIDDEN" {
+		t.Fatalf("started timer behaviors = %+v, %+v", started.TriggeringBehavior, started.CreationBehavior)
+	}
+
+	if err := client.Pause(started.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	paused, err := client.Get(started.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if paused.Status != "PAUSED" || paused.RemainingTimeWhenPaused == "" {
+		t.Fatalf("paused timer = %+v", paused)
+	}
+
+	if err := client.Resume(started.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if resumed, _ := client.Get(started.ID); resumed.Status != "ON" || resumed.RemainingTimeWhenPaused != "" {
+		t.Fatalf("resumed timer = %+v", resumed)
+	}
+
+	if timers, err := client.List(); err != nil || len(timers) != 1 {
+		t.Fatalf("List = %+v, %v", timers, err)
+	}
+
+	if err := client.Cancel(started.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Get(started.ID); err != alexa.ErrTimerNotFound {
+		t.Fatalf("Get after Cancel returned %v, want ErrTimerNotFound", err)
+	}
+}
+
+func TestTimerMaxExceeded(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetMaxTimers(1)
+	client := server.EchoRequest().Timers()
+
+	if _, err := client.Start(alexa.NewTimer(time.Minute, "tea")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Start(alexa.NewAnnounceTimer(time.Minute, "", "en-US", "Tea is ready")); err != alexa.ErrMaxTimersExceeded {
+		t.Fatalf("Start over the limit returned %v, want ErrMaxTimersExceeded", err)
+	}
+
+	if err := client.CancelAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if timers := server.Timers(); len(timers) != 0 {
+		t.Fatalf("%d timers left after CancelAll", len(timers))
+	}
+}
+
+func TestTimerPermissionMissing(t *testing.T) {
+	server := alexatest.NewServer()
+	defer server.Close()
+
+	server.SetPermission(alexa.TimersScope, false)
+	client := server.EchoRequest().Timers()
+
+	if _, err := client.Start(alexa.NewTimer(time.Minute, "")); err != alexa.ErrPermissionMissing {
+		t.Fatalf("Start without permission returned %v, want ErrPermissionMissing", err)
+	}
+
+	if _, err := client.List(); err != alexa.ErrPermissionMissing {
+		t.Fatalf("List without permission returned %v, want ErrPermissionMissing", err)
+	}
+}